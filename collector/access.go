@@ -0,0 +1,170 @@
+package collector
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// accessSessionsWorkers bounds the requests made at once while counting the active sessions
+const accessSessionsWorkers = 4
+
+type AccessApplication struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+}
+
+type AccessUser struct {
+	ID         string `json:"id"`
+	Email      string `json:"email"`
+	AccessSeat bool   `json:"access_seat"`
+}
+
+type AccessLogin struct {
+	Action     string `json:"action"`
+	Allowed    bool   `json:"allowed"`
+	AppUID     string `json:"app_uid"`
+	Connection string `json:"connection"`
+	UserEmail  string `json:"user_email"`
+}
+
+// outcome tells apart the allowed logins, the ones rejected on the MFA step and the ones blocked by a policy
+func (login AccessLogin) outcome() string {
+	if login.Allowed {
+		return "allowed"
+	}
+	if strings.Contains(strings.ToLower(login.Action), "mfa") {
+		return "failed_mfa"
+	}
+	return "blocked"
+}
+
+type accessLoginKey struct {
+	appID            string
+	identityProvider string
+	outcome          string
+}
+
+type AccessSessions struct {
+	Sessions map[string]json.RawMessage `json:"sessions"`
+}
+
+// accessSessions caches the number of active Access sessions. Counting them takes a request per user,
+// so it is done in the background and scrapes export the last count.
+type accessSessions struct {
+	mutex      sync.Mutex
+	count      int
+	counted    bool
+	updated    time.Time
+	refreshing bool
+}
+
+// get returns the last count, whether there is one, and when the last refresh finished
+func (sessions *accessSessions) get() (int, bool, time.Time) {
+	sessions.mutex.Lock()
+	defer sessions.mutex.Unlock()
+	return sessions.count, sessions.counted, sessions.updated
+}
+
+// refresh counts the sessions in the background, unless a count is already running
+func (sessions *accessSessions) refresh(accountID, mail, key string) {
+	sessions.mutex.Lock()
+	defer sessions.mutex.Unlock()
+	if sessions.refreshing {
+		return
+	}
+	sessions.refreshing = true
+	go func() {
+		count, err := getCloudflareAccessActiveSessions(accountID, mail, key)
+		sessions.mutex.Lock()
+		defer sessions.mutex.Unlock()
+		// A failed count also waits for the next refresh, so a broken API is not hammered
+		sessions.refreshing = false
+		sessions.updated = time.Now()
+		if err != nil {
+			log.Println("Unable to fetch Access users :", err)
+			return
+		}
+		sessions.count = count
+		sessions.counted = true
+	}()
+}
+
+// getCloudflareAccessLogins fetches the Access login events of the account between both dates
+func getCloudflareAccessLogins(startDate string, endDate string, accountID string, mail string, key string) ([]AccessLogin, error) {
+	logins := []AccessLogin{}
+	uri := apiURL + "/accounts/" + accountID + "/access/logs/access_requests?per_page=1000&since=" + url.QueryEscape(startDate) + "&until=" + url.QueryEscape(endDate)
+	err := getCloudflareRESTList(uri, mail, key, func(result json.RawMessage) error {
+		var page []AccessLogin
+		err := json.Unmarshal(result, &page)
+		logins = append(logins, page...)
+		return err
+	})
+	return logins, err
+}
+
+// getCloudflareAccessApplications maps the ID of every Access application of the account to its name
+func getCloudflareAccessApplications(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/access/apps", mail, key, func(result json.RawMessage) error {
+		var apps []AccessApplication
+		err := json.Unmarshal(result, &apps)
+		for _, app := range apps {
+			names[app.ID] = app.Name
+		}
+		return err
+	})
+	return names, err
+}
+
+// getCloudflareAccessActiveSessions counts the active sessions of every user holding an Access seat,
+// users whose sessions can't be fetched are skipped
+func getCloudflareAccessActiveSessions(accountID, mail, key string) (int, error) {
+	users := []AccessUser{}
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/access/users?per_page=100", mail, key, func(result json.RawMessage) error {
+		var page []AccessUser
+		err := json.Unmarshal(result, &page)
+		users = append(users, page...)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	seated := make(chan AccessUser)
+	counts := make(chan int)
+	var workers sync.WaitGroup
+	for i := 0; i < accessSessionsWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for user := range seated {
+				var sessions AccessSessions
+				err := getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/access/users/"+user.ID+"/active_sessions", mail, key, &sessions)
+				if err != nil {
+					log.Println("Unable to fetch the Access sessions of", user.Email, ":", err)
+					continue
+				}
+				counts <- len(sessions.Sessions)
+			}
+		}()
+	}
+	go func() {
+		for _, user := range users {
+			if user.AccessSeat {
+				seated <- user
+			}
+		}
+		close(seated)
+		workers.Wait()
+		close(counts)
+	}()
+	count := 0
+	for sessions := range counts {
+		count += sessions
+	}
+	return count, nil
+}
@@ -0,0 +1,25 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const accessLoginsPayload = `[
+	{"action": "login", "allowed": true, "app_uid": "df7e2w5f-02b7-4d9d-af26-8d1988fca630", "connection": "okta", "user_email": "alice@example.com"},
+	{"action": "login", "allowed": false, "app_uid": "df7e2w5f-02b7-4d9d-af26-8d1988fca630", "connection": "okta", "user_email": "bob@example.com"},
+	{"action": "mfa_failed", "allowed": false, "app_uid": "df7e2w5f-02b7-4d9d-af26-8d1988fca630", "connection": "okta", "user_email": "carol@example.com"}
+]`
+
+func TestAccessLoginOutcome(t *testing.T) {
+	var logins []AccessLogin
+	if err := json.Unmarshal([]byte(accessLoginsPayload), &logins); err != nil {
+		t.Fatalf("Error decoding the logins: %v", err)
+	}
+	expected := []string{"allowed", "blocked", "failed_mfa"}
+	for i, login := range logins {
+		if outcome := login.outcome(); outcome != expected[i] {
+			t.Errorf("Login %d: expected %s, got %s", i, expected[i], outcome)
+		}
+	}
+}
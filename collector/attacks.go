@@ -0,0 +1,56 @@
+package collector
+
+import "time"
+
+// attack keeps track of the lifecycle of a DDoS attack across scrapes
+type attack struct {
+	Start    time.Time `json:"start"`
+	LastSeen time.Time `json:"lastSeen"`
+	PeakBPS  float64   `json:"peakBps"`
+	PeakPPS  float64   `json:"peakPps"`
+	Active   bool      `json:"active"`
+}
+
+// attackTable stores the known attacks, keyed by attack ID
+type attackTable map[string]*attack
+
+// update records the traffic an attack generated on a given minute
+func (table attackTable) update(attackID string, minute time.Time, bits, packets int) {
+	a, ok := table[attackID]
+	if !ok {
+		a = &attack{Start: minute}
+		table[attackID] = a
+	}
+	if minute.Before(a.Start) {
+		a.Start = minute
+	}
+	if end := minute.Add(time.Minute); end.After(a.LastSeen) {
+		a.LastSeen = end
+	}
+	if bps := float64(bits) / 60; bps > a.PeakBPS {
+		a.PeakBPS = bps
+	}
+	if pps := float64(packets) / 60; pps > a.PeakPPS {
+		a.PeakPPS = pps
+	}
+	a.Active = true
+}
+
+// expire removes the attacks that have not been seen during the quiet period
+func (table attackTable) expire(now time.Time, quietPeriod time.Duration) {
+	for id, a := range table {
+		if now.Sub(a.LastSeen) > quietPeriod {
+			delete(table, id)
+		}
+	}
+}
+
+func loadAttackTable(path string) (attackTable, error) {
+	table := make(attackTable)
+	err := loadStateFile(path, &table)
+	return table, err
+}
+
+func (table attackTable) save(path string) error {
+	return saveStateFile(path, table)
+}
@@ -0,0 +1,139 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"time"
+)
+
+type AuditLog struct {
+	ID       string        `json:"id"`
+	When     time.Time     `json:"when"`
+	Action   AuditAction   `json:"action"`
+	Actor    AuditActor    `json:"actor"`
+	Resource AuditResource `json:"resource"`
+}
+
+type AuditAction struct {
+	Type   string `json:"type"`
+	Result bool   `json:"result"`
+}
+
+type AuditActor struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Type  string `json:"type"`
+}
+
+type AuditResource struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// name returns the email of the actor, or its type and ID for API tokens and system actions
+func (actor AuditActor) name() string {
+	if actor.Email != "" {
+		return actor.Email
+	}
+	if actor.ID == "" {
+		return actor.Type
+	}
+	return actor.Type + ":" + actor.ID
+}
+
+// auditEvent is an audit log along with the JSON it was decoded from, so it can be forwarded untouched
+type auditEvent struct {
+	AuditLog
+	Raw json.RawMessage
+}
+
+type auditKey struct {
+	actor        string
+	resourceType string
+	action       string
+}
+
+// auditCursor is the position of the audit log stream, the IDs of the events
+// sharing the Since timestamp are kept so they are not counted twice
+type auditCursor struct {
+	Since time.Time `json:"since"`
+	IDs   []string  `json:"ids"`
+}
+
+// accept reports whether the event is past the cursor, moving the cursor forward when it is.
+// Events must be accepted in chronological order.
+func (cursor *auditCursor) accept(id string, when time.Time) bool {
+	if when.Before(cursor.Since) {
+		return false
+	}
+	if when.Equal(cursor.Since) {
+		if contains(cursor.IDs, id) {
+			return false
+		}
+		cursor.IDs = append(cursor.IDs, id)
+		return true
+	}
+	cursor.Since = when
+	cursor.IDs = []string{id}
+	return true
+}
+
+func loadAuditCursor(path string) (cursor auditCursor, err error) {
+	err = loadStateFile(path, &cursor)
+	return cursor, err
+}
+
+func (cursor auditCursor) save(path string) error {
+	return saveStateFile(path, cursor)
+}
+
+func getCloudflareAuditLogs(accountID string, since time.Time, mail, key string) ([]auditEvent, error) {
+	var events []auditEvent
+	uri := apiURL + "/accounts/" + accountID + "/audit_logs?direction=asc&per_page=1000&since=" + url.QueryEscape(since.UTC().Format(time.RFC3339))
+	err := getCloudflareRESTList(uri, mail, key, func(result json.RawMessage) error {
+		var page []json.RawMessage
+		err := json.Unmarshal(result, &page)
+		if err != nil {
+			return err
+		}
+		for _, raw := range page {
+			event := auditEvent{Raw: raw}
+			err = json.Unmarshal(raw, &event.AuditLog)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+// openAuditOutput opens the destination of the forwarded events, "-" stands for stdout
+func openAuditOutput(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// writeAuditEvent writes the event as a single JSON line
+func writeAuditEvent(w io.Writer, event auditEvent) error {
+	var line bytes.Buffer
+	err := json.Compact(&line, event.Raw)
+	if err != nil {
+		return err
+	}
+	line.WriteByte('\n')
+	_, err = w.Write(line.Bytes())
+	return err
+}
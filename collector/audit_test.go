@@ -0,0 +1,32 @@
+package collector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuditCursor(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := auditCursor{Since: start, IDs: []string{"a"}}
+
+	expected := []struct {
+		id     string
+		when   time.Time
+		accept bool
+	}{
+		{"a", start, false},
+		{"b", start, true},
+		{"b", start, false},
+		{"c", start.Add(time.Second), true},
+		{"d", start, false},
+		{"e", start.Add(time.Second), true},
+	}
+	for _, e := range expected {
+		if accepted := cursor.accept(e.id, e.when); accepted != e.accept {
+			t.Errorf("Event %s accepted %v, expected %v", e.id, accepted, e.accept)
+		}
+	}
+	if !cursor.Since.Equal(start.Add(time.Second)) || len(cursor.IDs) != 2 {
+		t.Errorf("Unexpected cursor %v %v", cursor.Since, cursor.IDs)
+	}
+}
@@ -0,0 +1,104 @@
+package collector
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Subscription struct {
+	ID                 string                  `json:"id"`
+	Price              float64                 `json:"price"`
+	Currency           string                  `json:"currency"`
+	Frequency          string                  `json:"frequency"`
+	State              string                  `json:"state"`
+	CurrentPeriodStart time.Time               `json:"current_period_start"`
+	CurrentPeriodEnd   time.Time               `json:"current_period_end"`
+	Product            SubscriptionProduct     `json:"product"`
+	RatePlan           SubscriptionRatePlan    `json:"rate_plan"`
+	ComponentValues    []SubscriptionComponent `json:"component_values"`
+}
+
+type SubscriptionProduct struct {
+	Name string `json:"name"`
+}
+
+type SubscriptionRatePlan struct {
+	ID         string `json:"id"`
+	PublicName string `json:"public_name"`
+}
+
+// SubscriptionComponent is a component of the subscription, Value being the quantity assigned to it rather than the one consumed
+type SubscriptionComponent struct {
+	Name    string  `json:"name"`
+	Value   float64 `json:"value"`
+	Default float64 `json:"default"`
+	Price   float64 `json:"price"`
+}
+
+// productName returns the most descriptive name the subscription has
+func (s Subscription) productName() string {
+	if s.Product.Name != "" {
+		return s.Product.Name
+	}
+	if s.RatePlan.PublicName != "" {
+		return s.RatePlan.PublicName
+	}
+	return s.RatePlan.ID
+}
+
+// UsageRecord is the consumption of a usage-based product over a charge period, as reported on the billing usage
+type UsageRecord struct {
+	ServiceName      string  `json:"ServiceName"`
+	ConsumedQuantity float64 `json:"ConsumedQuantity"`
+	ConsumedUnit     string  `json:"ConsumedUnit"`
+	ContractedCost   float64 `json:"ContractedCost"`
+	BillingCurrency  string  `json:"BillingCurrency"`
+}
+
+// usageKey identifies a product and the unit its usage is measured in, the currency for costs
+type usageKey struct {
+	service string
+	unit    string
+}
+
+// sumUsage adds up the quantities and the costs of the charge periods of every product
+func sumUsage(records []UsageRecord) (quantities map[usageKey]float64, costs map[usageKey]float64) {
+	quantities = make(map[usageKey]float64)
+	costs = make(map[usageKey]float64)
+	for _, record := range records {
+		quantities[usageKey{record.ServiceName, record.ConsumedUnit}] += record.ConsumedQuantity
+		costs[usageKey{record.ServiceName, record.BillingCurrency}] += record.ContractedCost
+	}
+	return quantities, costs
+}
+
+// billingPeriodStart returns the start of the month usage-based products are currently billed for
+func billingPeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// getCloudflareUsage fetches the consumption of the usage-based products of the account on the current billing period
+func getCloudflareUsage(accountID, mail, key string) ([]UsageRecord, error) {
+	var records []UsageRecord
+	now := time.Now().UTC()
+	uri := apiURL + "/accounts/" + accountID + "/billing/usage/paygo?from=" + billingPeriodStart(now).Format("2006-01-02") + "&to=" + now.Format("2006-01-02")
+	err := getCloudflareRESTResult(uri, mail, key, &records)
+	return records, err
+}
+
+func getCloudflareAccountSubscriptions(accountID, mail, key string) ([]Subscription, error) {
+	var subscriptions []Subscription
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/subscriptions?per_page=50", mail, key, func(result json.RawMessage) error {
+		var page []Subscription
+		err := json.Unmarshal(result, &page)
+		subscriptions = append(subscriptions, page...)
+		return err
+	})
+	return subscriptions, err
+}
+
+func getCloudflareZoneSubscription(zoneID, mail, key string) (subscription Subscription, err error) {
+	err = getCloudflareRESTResult(apiURL+"/zones/"+zoneID+"/subscription", mail, key, &subscription)
+	return subscription, err
+}
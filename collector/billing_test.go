@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const usagePayload = `[
+	{"ChargePeriodStart": "2026-10-01T00:00:00Z", "ServiceName": "Workers Standard", "ConsumedQuantity": 1200000, "ConsumedUnit": "Requests", "ContractedCost": 0.36, "BillingCurrency": "USD"},
+	{"ChargePeriodStart": "2026-10-02T00:00:00Z", "ServiceName": "Workers Standard", "ConsumedQuantity": 800000, "ConsumedUnit": "Requests", "ContractedCost": 0.24, "BillingCurrency": "USD"},
+	{"ChargePeriodStart": "2026-10-02T00:00:00Z", "ServiceName": "R2 Storage", "ConsumedQuantity": 15.5, "ConsumedUnit": "GB-month", "ContractedCost": 0.23, "BillingCurrency": "USD"}
+]`
+
+func TestSumUsage(t *testing.T) {
+	var records []UsageRecord
+	if err := json.Unmarshal([]byte(usagePayload), &records); err != nil {
+		t.Fatalf("Error decoding the usage: %v", err)
+	}
+	quantities, costs := sumUsage(records)
+	if quantity := quantities[usageKey{"Workers Standard", "Requests"}]; quantity != 2000000 {
+		t.Errorf("Unexpected Workers quantity %f", quantity)
+	}
+	if cost := costs[usageKey{"Workers Standard", "USD"}]; cost < 0.599 || cost > 0.601 {
+		t.Errorf("Unexpected Workers cost %f", cost)
+	}
+	if len(quantities) != 2 || len(costs) != 2 {
+		t.Errorf("Unexpected usage %v %v", quantities, costs)
+	}
+}
+
+func TestBillingPeriodStart(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 15, 4, 5, 0, time.UTC)
+	if start := billingPeriodStart(now); !start.Equal(time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected period start %v", start)
+	}
+}
@@ -0,0 +1,95 @@
+package collector
+
+import "strconv"
+
+// botsTopFingerprints limits the number of JA3/JA4 fingerprints exported per zone
+const botsTopFingerprints = 10
+
+// botScoreBucket groups a bot score in the same ranges used by the Bot Analytics dashboard
+func botScoreBucket(score int) string {
+	switch {
+	case score == 0:
+		return "not_computed"
+	case score == 1:
+		return "automated"
+	case score < 30:
+		return "likely_automated"
+	default:
+		return "likely_human"
+	}
+}
+
+func getCloudflareBotsMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			zones(filter: { zoneTag: $zoneTag }) {
+				botScores: httpRequestsAdaptiveGroups(
+					limit: 100
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						botScore
+					}
+				}
+				botCategories: httpRequestsAdaptiveGroups(
+					limit: 100
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate, verifiedBotCategory_neq: ""}
+				) {
+					count
+					dimensions {
+						verifiedBotCategory
+					}
+				}
+				botJA3: httpRequestsAdaptiveGroups(
+					limit: ` + strconv.Itoa(botsTopFingerprints) + `
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate, ja3Hash_neq: ""}
+					orderBy: [count_DESC]
+				) {
+					count
+					dimensions {
+						ja3Hash
+					}
+				}
+				botJA4: httpRequestsAdaptiveGroups(
+					limit: ` + strconv.Itoa(botsTopFingerprints) + `
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate, ja4_neq: ""}
+					orderBy: [count_DESC]
+				) {
+					count
+					dimensions {
+						ja4
+					}
+				}
+				botSources: httpRequestsAdaptiveGroups(
+					limit: 100
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						botScoreSrcName
+					}
+				}
+				botActions: firewallEventsAdaptiveGroups(
+					limit: 100
+					filter: {
+						datetime_geq: $startDate, datetime_leq: $endDate,
+						source_in: ["botFight", "botManagement"],
+						action_in: ["block", "challenge", "jschallenge", "managed_challenge"]
+					}
+				) {
+					count
+					dimensions {
+						source
+						action
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, zoneID, "")
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
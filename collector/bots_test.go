@@ -0,0 +1,44 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const botsPayload = `{
+	"viewer": {
+		"zones": [{
+			"botScores": [
+				{"count": 12, "dimensions": {"botScore": 1}},
+				{"count": 30, "dimensions": {"botScore": 95}}
+			],
+			"botCategories": [{"count": 4, "dimensions": {"verifiedBotCategory": "Search Engine Crawler"}}],
+			"botJA3": [{"count": 7, "dimensions": {"ja3Hash": "cd08e31494f9531f560d64c695473da9"}}],
+			"botJA4": [{"count": 7, "dimensions": {"ja4": "t13d1516h2_8daaf6152771_b186095e22b6"}}],
+			"botSources": [{"count": 42, "dimensions": {"botScoreSrcName": "Machine Learning"}}],
+			"botActions": [{"count": 3, "dimensions": {"source": "botManagement", "action": "managed_challenge"}}]
+		}]
+	}
+}`
+
+func TestBotsResponse(t *testing.T) {
+	var resp RespDataStruct
+	if err := json.Unmarshal([]byte(botsPayload), &resp); err != nil {
+		t.Fatalf("Error decoding the response: %v", err)
+	}
+	zone := resp.Viewer.Zones[0]
+	if bucket := botScoreBucket(zone.BotScores[0].Dimensions.BotScore); bucket != "automated" {
+		t.Errorf("Unexpected bucket %s", bucket)
+	}
+	if bucket := botScoreBucket(zone.BotScores[1].Dimensions.BotScore); bucket != "likely_human" {
+		t.Errorf("Unexpected bucket %s", bucket)
+	}
+	if zone.BotCategories[0].Dimensions.VerifiedBotCategory != "Search Engine Crawler" ||
+		zone.BotJA3[0].Dimensions.JA3Hash == "" || zone.BotJA4[0].Dimensions.JA4 == "" ||
+		zone.BotSources[0].Dimensions.BotScoreSrcName != "Machine Learning" {
+		t.Errorf("Unexpected dimensions %+v", zone)
+	}
+	if action := zone.BotActions[0]; action.Count != 3 || action.Dimensions.Action != "managed_challenge" || action.Dimensions.Source != "botManagement" {
+		t.Errorf("Unexpected bot action %+v", action)
+	}
+}
@@ -0,0 +1,69 @@
+package collector
+
+// cacheHitStatuses are the cache statuses served without reaching the origin
+var cacheHitStatuses = []string{"hit", "stale", "updating", "revalidated"}
+
+// cacheTier returns whether a request was fetched through an upper tier data center or not
+func cacheTier(upperTierColoName string) string {
+	if upperTierColoName == "" {
+		return "lower"
+	}
+	return "upper"
+}
+
+func getCloudflareCacheMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			zones(filter: { zoneTag: $zoneTag }) {
+				cacheTiers: httpRequestsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					sum {
+						edgeResponseBytes
+					}
+					dimensions {
+						cacheStatus
+						upperTierColoName
+					}
+				}
+				cacheHosts: httpRequestsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						cacheStatus
+						clientRequestHTTPHost
+					}
+				}
+				cacheReserveRequests: cacheReserveRequestsAdaptiveGroups(
+					limit: 100
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						requests
+					}
+					dimensions {
+						operationClass
+					}
+				}
+				cacheReserveStorage: cacheReserveStorageAdaptiveGroups(
+					limit: 1
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					max {
+						storedBytes
+						objectCount
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, zoneID, "")
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
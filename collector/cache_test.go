@@ -0,0 +1,54 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const cachePayload = `{
+	"viewer": {
+		"zones": [{
+			"cacheTiers": [
+				{"count": 80, "sum": {"edgeResponseBytes": 409600}, "dimensions": {"cacheStatus": "hit", "upperTierColoName": ""}},
+				{"count": 20, "sum": {"edgeResponseBytes": 102400}, "dimensions": {"cacheStatus": "miss", "upperTierColoName": "ORD"}}
+			],
+			"cacheHosts": [
+				{"count": 75, "dimensions": {"cacheStatus": "hit", "clientRequestHTTPHost": "www.example.com"}},
+				{"count": 5, "dimensions": {"cacheStatus": "revalidated", "clientRequestHTTPHost": "www.example.com"}},
+				{"count": 20, "dimensions": {"cacheStatus": "miss", "clientRequestHTTPHost": "www.example.com"}}
+			],
+			"cacheReserveRequests": [{"sum": {"requests": 12}, "dimensions": {"operationClass": "classA"}}],
+			"cacheReserveStorage": [{"max": {"storedBytes": 5368709120, "objectCount": 3400}}]
+		}]
+	}
+}`
+
+func TestCacheResponse(t *testing.T) {
+	var resp RespDataStruct
+	if err := json.Unmarshal([]byte(cachePayload), &resp); err != nil {
+		t.Fatalf("Error decoding the response: %v", err)
+	}
+	zone := resp.Viewer.Zones[0]
+	if tier := cacheTier(zone.CacheTiers[0].Dimensions.UpperTierColoName); tier != "lower" {
+		t.Errorf("Unexpected tier %s", tier)
+	}
+	if tier := cacheTier(zone.CacheTiers[1].Dimensions.UpperTierColoName); tier != "upper" {
+		t.Errorf("Unexpected tier %s", tier)
+	}
+	hits, total := 0, 0
+	for _, node := range zone.CacheHosts {
+		total += node.Count
+		if contains(cacheHitStatuses, node.Dimensions.CacheStatus) {
+			hits += node.Count
+		}
+	}
+	if hits != 80 || total != 100 {
+		t.Errorf("Unexpected hits %d out of %d", hits, total)
+	}
+	if zone.CacheReserveRequests[0].Sum.Requests != 12 || zone.CacheReserveRequests[0].Dimensions.OperationClass != "classA" {
+		t.Errorf("Unexpected reserve operations %+v", zone.CacheReserveRequests[0])
+	}
+	if storage := zone.CacheReserveStorage[0].Max; storage.StoredBytes != 5368709120 || storage.ObjectCount != 3400 {
+		t.Errorf("Unexpected reserve storage %+v", storage)
+	}
+}
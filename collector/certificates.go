@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type CertificatePack struct {
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	Status       string        `json:"status"`
+	Hosts        []string      `json:"hosts"`
+	Certificates []Certificate `json:"certificates"`
+}
+
+type Certificate struct {
+	ID        string   `json:"id"`
+	Hosts     []string `json:"hosts"`
+	Hostnames []string `json:"hostnames"`
+	Issuer    string   `json:"issuer"`
+	Status    string   `json:"status"`
+	ExpiresOn string   `json:"expires_on"`
+}
+
+// ZoneCertificate is a certificate covering a zone, regardless of where it is managed
+type ZoneCertificate struct {
+	ID        string
+	Source    string
+	Hosts     string
+	Issuer    string
+	Status    string
+	ExpiresOn time.Time
+}
+
+// certificateTimeLayouts are the formats the certificate endpoints use on expires_on
+var certificateTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05 -0700 MST"}
+
+func parseCertificateTime(value string) time.Time {
+	for _, layout := range certificateTimeLayouts {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func newZoneCertificate(certificate Certificate, source, status string) ZoneCertificate {
+	hosts := append(append([]string{}, certificate.Hosts...), certificate.Hostnames...)
+	sort.Strings(hosts)
+	if certificate.Status != "" {
+		status = certificate.Status
+	}
+	return ZoneCertificate{
+		ID:        certificate.ID,
+		Source:    source,
+		Hosts:     strings.Join(hosts, ","),
+		Issuer:    certificate.Issuer,
+		Status:    status,
+		ExpiresOn: parseCertificateTime(certificate.ExpiresOn),
+	}
+}
+
+// getCloudflareCertificates lists the certificates of a zone from every source, a source that
+// fails to be fetched is reported on the error without hiding the certificates of the others
+func getCloudflareCertificates(zoneID, mail, key string) ([]ZoneCertificate, error) {
+	certificates := []ZoneCertificate{}
+	failures := []string{}
+	err := getCloudflareRESTList(apiURL+"/zones/"+zoneID+"/ssl/certificate_packs?status=all", mail, key, func(result json.RawMessage) error {
+		var packs []CertificatePack
+		err := json.Unmarshal(result, &packs)
+		for _, pack := range packs {
+			// Packs are reported on their own, so the ones still being ordered have a status too
+			hosts := append([]string{}, pack.Hosts...)
+			sort.Strings(hosts)
+			certificates = append(certificates, ZoneCertificate{ID: pack.ID, Source: "edge_pack", Hosts: strings.Join(hosts, ","), Status: pack.Status})
+			for _, certificate := range pack.Certificates {
+				certificates = append(certificates, newZoneCertificate(certificate, "edge", pack.Status))
+			}
+		}
+		return err
+	})
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+	err = getCloudflareRESTList(apiURL+"/zones/"+zoneID+"/custom_certificates", mail, key, func(result json.RawMessage) error {
+		var custom []Certificate
+		err := json.Unmarshal(result, &custom)
+		for _, certificate := range custom {
+			certificates = append(certificates, newZoneCertificate(certificate, "custom", ""))
+		}
+		return err
+	})
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+	err = getCloudflareRESTList(apiURL+"/certificates?zone_id="+zoneID, mail, key, func(result json.RawMessage) error {
+		var origin []Certificate
+		err := json.Unmarshal(result, &origin)
+		for _, certificate := range origin {
+			certificates = append(certificates, newZoneCertificate(certificate, "origin_ca", "active"))
+		}
+		return err
+	})
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+	if len(failures) > 0 {
+		return certificates, errors.New(strings.Join(failures, "; "))
+	}
+	return certificates, nil
+}
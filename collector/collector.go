@@ -2,6 +2,7 @@ package collector
 
 import (
 	"errors"
+	"io"
 	"log"
 	"strconv"
 	"strings"
@@ -14,6 +15,11 @@ import (
 
 const (
 	namespace = "cloudflare"
+
+	// queryWindow is the span of time every scrape requests from the analytics API
+	queryWindow = 15 * time.Minute
+	// queryLag is how far behind the current time the window ends, so the analytics have been ingested
+	queryLag = 5 * time.Minute
 )
 
 type metricInfo struct {
@@ -23,9 +29,14 @@ type metricInfo struct {
 type metrics map[string]metricInfo
 
 func addMetric(metrics map[string]metricInfo, submodule string, metricName string, docString string, t prometheus.ValueType, labels []string) {
+	addMetricWithKey(metrics, metricName, submodule, metricName, docString, t, labels)
+}
+
+// addMetricWithKey registers a metric under a custom key, so submodules can export metrics that share the same name
+func addMetricWithKey(metrics map[string]metricInfo, metricKey string, submodule string, metricName string, docString string, t prometheus.ValueType, labels []string) {
 	key := prometheus.BuildFQName(namespace, submodule, metricName)
 	// log.Printf("Registered metric %s with labels %v\n", key, labels)
-	metrics[metricName] = metricInfo{
+	metrics[metricKey] = metricInfo{
 		Desc: prometheus.NewDesc(
 			key,
 			docString,
@@ -51,6 +62,31 @@ type CloudflareCollector struct {
 	startDate string
 	endDate   string
 
+	latencyQuantiles []float64
+
+	rulesetRefresh time.Duration
+	rules          map[string]ruleDescriptions
+
+	accessSessionsRefresh time.Duration
+	accessSessions        accessSessions
+
+	activations zoneActivations
+
+	crons         cronTable
+	cronStateFile string
+
+	attacks           attackTable
+	attackStateFile   string
+	attackQuietPeriod time.Duration
+
+	dnsDesiredState  string
+	compliancePolicy string
+
+	auditCursor    auditCursor
+	auditStateFile string
+	auditOutput    string
+	auditCounts    map[auditKey]float64
+
 	cfMetrics map[string]metricInfo
 
 	mutex sync.Mutex
@@ -65,30 +101,194 @@ func (collector *CloudflareCollector) updateMetric(metricName string, value floa
 	return prometheus.MustNewConstMetric(metric.Desc, metric.Type, value, labelValues...)
 }
 
-// NewCloudflareCollector returns an initialized Collector.
-func New(apiKey, apiMail, AccountID, zoneName, dataset string) *CloudflareCollector {
+// Config stores the parameters used to build a CloudflareCollector
+type Config struct {
+	APIKey    string
+	APIEmail  string
+	AccountID string
+	ZoneName  string
+	Dataset   string
+
+	// LatencyQuantiles is a comma separated list of the quantiles exported on the latency metrics
+	LatencyQuantiles string
+	// RulesetRefresh is how often the WAF rule descriptions are fetched again from the rulesets API
+	RulesetRefresh string
+	// CronStateFile is where the last successful run of every cron trigger is persisted, leave it empty to keep it in memory
+	CronStateFile string
+	// AccessSessionsRefresh is how often the active Access sessions are counted again
+	AccessSessionsRefresh string
+	// AttackStateFile is where the DDoS attack table is persisted, leave it empty to keep it in memory
+	AttackStateFile string
+	// AttackQuietPeriod is how long an attack must go unseen before it is expired
+	AttackQuietPeriod string
+	// DNSDesiredState is the YAML file the DNS records are compared against
+	DNSDesiredState string
+	// CompliancePolicy is the YAML file with the rules the zone settings are checked against
+	CompliancePolicy string
+	// AuditStateFile is where the audit log cursor is persisted, leave it empty to keep it in memory
+	AuditStateFile string
+	// AuditOutput is the file the audit log events are forwarded to as JSON lines, "-" stands for stdout
+	AuditOutput string
+}
+
+// New returns an initialized Collector.
+func New(config Config) *CloudflareCollector {
 
 	c := CloudflareCollector{
-		apiKey:    apiKey,
-		apiEmail:  apiMail,
-		accountID: AccountID,
-		zoneName:  zoneName,
-		dataset:   strings.Split(dataset, ","),
+		apiKey:    config.APIKey,
+		apiEmail:  config.APIEmail,
+		accountID: config.AccountID,
+		zoneName:  config.ZoneName,
+		dataset:   strings.Split(config.Dataset, ","),
+
+		attackStateFile:  config.AttackStateFile,
+		cronStateFile:    config.CronStateFile,
+		dnsDesiredState:  config.DNSDesiredState,
+		compliancePolicy: config.CompliancePolicy,
+		auditStateFile:   config.AuditStateFile,
+		auditOutput:      config.AuditOutput,
+	}
+
+	var err error
+	c.latencyQuantiles, err = parseQuantiles(config.LatencyQuantiles)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	c.rulesetRefresh, err = time.ParseDuration(config.RulesetRefresh)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.rules = make(map[string]ruleDescriptions)
+	c.accessSessionsRefresh, err = time.ParseDuration(config.AccessSessionsRefresh)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.crons, err = loadCronTable(c.cronStateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.attackQuietPeriod, err = time.ParseDuration(config.AttackQuietPeriod)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.attacks, err = loadAttackTable(c.attackStateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.auditCursor, err = loadAuditCursor(c.auditStateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if c.auditCursor.Since.IsZero() {
+		c.auditCursor.Since = time.Now()
 	}
+	c.auditCounts = make(map[auditKey]float64)
 
 	c.cfMetrics = make(map[string]metricInfo)
 
-	addMetric(c.cfMetrics, "worker", "cputime", "CPU time consumed by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "percentile"})
-	addMetric(c.cfMetrics, "worker", "errors", "Errors trigered by worker", prometheus.GaugeValue, []string{"workerName", "accountName"})
-	addMetric(c.cfMetrics, "worker", "requests", "Requests received by worker", prometheus.GaugeValue, []string{"workerName", "accountName"})
-	addMetric(c.cfMetrics, "worker", "subrequests", "Subrequests performed by worker", prometheus.GaugeValue, []string{"workerName", "accountName"})
+	addMetric(c.cfMetrics, "worker", "cputime", "CPU time consumed by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "percentile", "status", "usageModel", "environment", "dispatchNamespace"})
+	addMetric(c.cfMetrics, "worker", "walltime", "Wall time consumed by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "percentile", "status", "usageModel", "environment", "dispatchNamespace"})
+	addMetric(c.cfMetrics, "worker", "duration", "Duration of the worker invocations in GB-s", prometheus.GaugeValue, []string{"workerName", "accountName", "percentile", "status", "usageModel", "environment", "dispatchNamespace"})
+	addMetric(c.cfMetrics, "worker", "errors", "Errors trigered by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "status", "usageModel", "environment", "dispatchNamespace"})
+	addMetric(c.cfMetrics, "worker", "requests", "Requests received by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "status", "usageModel", "environment", "dispatchNamespace"})
+	addMetric(c.cfMetrics, "worker", "subrequests", "Subrequests performed by worker", prometheus.GaugeValue, []string{"workerName", "accountName", "status", "usageModel", "environment", "dispatchNamespace"})
+
+	addMetric(c.cfMetrics, "worker", "cron_invocations", "Scheduled invocations of the worker, labelled per cron and status", prometheus.GaugeValue, []string{"workerName", "accountName", "cron", "status"})
+	addMetric(c.cfMetrics, "worker", "cron_cputime", "Maximum CPU time consumed by the scheduled invocations in microseconds", prometheus.GaugeValue, []string{"workerName", "accountName", "cron"})
+	addMetric(c.cfMetrics, "worker", "cron_seconds_since_last_success", "Seconds elapsed since the last successful scheduled invocation, or since the trigger was first seen if it never succeeded", prometheus.GaugeValue, []string{"workerName", "accountName", "cron"})
+
+	addMetric(c.cfMetrics, "pages", "functions_cputime", "CPU time consumed by Pages Functions", prometheus.GaugeValue, []string{"project", "accountName", "percentile", "status"})
+	addMetric(c.cfMetrics, "pages", "functions_errors", "Errors trigered by Pages Functions", prometheus.GaugeValue, []string{"project", "accountName", "status"})
+	addMetric(c.cfMetrics, "pages", "functions_requests", "Requests received by Pages Functions", prometheus.GaugeValue, []string{"project", "accountName", "status"})
+	addMetric(c.cfMetrics, "pages", "functions_subrequests", "Subrequests performed by Pages Functions", prometheus.GaugeValue, []string{"project", "accountName", "status"})
+
+	addMetric(c.cfMetrics, "tunnel", "status", "Whether the tunnel is in the given status", prometheus.GaugeValue, []string{"tunnelID", "tunnelName", "status", "accountName"})
+	addMetric(c.cfMetrics, "tunnel", "active_connections", "Active connections of the tunnel, labelled per colo", prometheus.GaugeValue, []string{"tunnelID", "tunnelName", "colo", "accountName"})
+	addMetric(c.cfMetrics, "tunnel", "connector_versions", "Connections of the tunnel, labelled per cloudflared version", prometheus.GaugeValue, []string{"tunnelID", "tunnelName", "version", "accountName"})
+	addMetric(c.cfMetrics, "tunnel", "seconds_since_last_change", "Seconds elapsed since the tunnel gained or lost all its connections", prometheus.GaugeValue, []string{"tunnelID", "tunnelName", "accountName"})
+
+	addMetric(c.cfMetrics, "gateway", "dns_queries", "Gateway DNS queries, labelled per policy and resolver decision", prometheus.GaugeValue, []string{"policyID", "policy", "decision", "accountName"})
+	addMetric(c.cfMetrics, "gateway", "dns_queries_by_category", "Gateway DNS queries, labelled per category and resolver decision", prometheus.GaugeValue, []string{"category", "decision", "accountName"})
+	addMetric(c.cfMetrics, "gateway", "http_requests", "Gateway HTTP requests, labelled per policy and action", prometheus.GaugeValue, []string{"policyID", "policy", "action", "accountName"})
+	addMetric(c.cfMetrics, "gateway", "network_sessions", "Gateway network sessions, labelled per policy and action", prometheus.GaugeValue, []string{"policyID", "policy", "action", "accountName"})
+
+	addMetric(c.cfMetrics, "access", "logins", "Access login attempts, labelled per application, identity provider and outcome", prometheus.GaugeValue, []string{"appID", "application", "identityProvider", "outcome", "accountName"})
+	addMetric(c.cfMetrics, "access", "active_sessions", "Number of active Access sessions", prometheus.GaugeValue, []string{"accountName"})
+
+	addMetric(c.cfMetrics, "rum", "pageloads", "Page loads reported by Web Analytics", prometheus.GaugeValue, []string{"site", "pathPrefix", "country", "deviceType", "accountName"})
+	for _, name := range rumVitals {
+		addMetric(c.cfMetrics, "rum", name, "Core Web Vital reported by Web Analytics, an approximation computed as the mean of the percentiles of the paths of the prefix weighted by their number of events", prometheus.GaugeValue, []string{"site", "pathPrefix", "country", "deviceType", "accountName", "weightedPercentile"})
+	}
+
+	addMetric(c.cfMetrics, "storage", "kv_operations", "Workers KV operations, labelled per namespace and action type", prometheus.GaugeValue, []string{"namespace", "actionType", "accountName"})
+	addMetric(c.cfMetrics, "storage", "r2_operations", "R2 operations, labelled per bucket and action type", prometheus.GaugeValue, []string{"bucket", "actionType", "accountName"})
+	addMetric(c.cfMetrics, "storage", "r2_stored_bytes", "Bytes stored on the R2 bucket", prometheus.GaugeValue, []string{"bucket", "accountName"})
+	addMetric(c.cfMetrics, "storage", "r2_objects", "Number of objects stored on the R2 bucket", prometheus.GaugeValue, []string{"bucket", "accountName"})
+	addMetric(c.cfMetrics, "storage", "durable_objects_requests", "Durable Objects requests, labelled per namespace", prometheus.GaugeValue, []string{"namespace", "accountName"})
+	addMetric(c.cfMetrics, "storage", "durable_objects_walltime", "Wall time consumed by the Durable Objects of the namespace in microseconds", prometheus.GaugeValue, []string{"namespace", "accountName"})
+	addMetric(c.cfMetrics, "storage", "durable_objects_stored_bytes", "Bytes stored by the Durable Objects of the account, the analytics API does not break it down per namespace", prometheus.GaugeValue, []string{"accountName"})
+
+	addMetric(c.cfMetrics, "d1", "read_queries", "Read queries executed on the D1 database", prometheus.GaugeValue, []string{"database", "accountName"})
+	addMetric(c.cfMetrics, "d1", "write_queries", "Write queries executed on the D1 database", prometheus.GaugeValue, []string{"database", "accountName"})
+	addMetric(c.cfMetrics, "d1", "rows_read", "Rows read from the D1 database", prometheus.GaugeValue, []string{"database", "accountName"})
+	addMetric(c.cfMetrics, "d1", "rows_written", "Rows written to the D1 database", prometheus.GaugeValue, []string{"database", "accountName"})
+	addMetric(c.cfMetrics, "d1", "query_batch_milliseconds", "Time spent running query batches on the D1 database", prometheus.GaugeValue, []string{"database", "accountName", "percentile"})
+	addMetric(c.cfMetrics, "d1", "database_size_bytes", "Size of the D1 database", prometheus.GaugeValue, []string{"database", "accountName"})
+
+	addMetric(c.cfMetrics, "queues", "messages_produced", "Messages written to the queue", prometheus.GaugeValue, []string{"queue", "accountName"})
+	addMetric(c.cfMetrics, "queues", "messages_consumed", "Messages acknowledged by the queue consumers", prometheus.GaugeValue, []string{"queue", "accountName"})
+	addMetric(c.cfMetrics, "queues", "messages_retried", "Messages retried by the queue consumers", prometheus.GaugeValue, []string{"queue", "accountName"})
+	addMetric(c.cfMetrics, "queues", "messages_dead_lettered", "Messages sent to the dead letter queue", prometheus.GaugeValue, []string{"queue", "accountName"})
+	addMetric(c.cfMetrics, "queues", "backlog_messages", "Average number of messages waiting on the queue", prometheus.GaugeValue, []string{"queue", "accountName"})
+	addMetric(c.cfMetrics, "queues", "backlog_bytes", "Average number of bytes waiting on the queue", prometheus.GaugeValue, []string{"queue", "accountName"})
 
 	addMetric(c.cfMetrics, "net", "bits", "Number of bits, labelled per AttackID", prometheus.GaugeValue, []string{"attackID", "accountName", "attackProtocol", "mitigationType", "country", "destinationPort", "attackType"})
 	addMetric(c.cfMetrics, "net", "packets", "Number of packets, labelled per AttackID", prometheus.GaugeValue, []string{"attackID", "accountName", "attackProtocol", "mitigationType", "country", "destinationPort", "attackType"})
 
-	addMetric(c.cfMetrics, "waf", "events", "Cloudflare WAF Hits", prometheus.GaugeValue, []string{"as", "country", "action", "ruleID", "zoneName"})
+	addMetric(c.cfMetrics, "net", "attack_active", "Whether the attack was seen on the last scrape", prometheus.GaugeValue, []string{"attackID", "accountName"})
+	addMetric(c.cfMetrics, "net", "attack_start_timestamp_seconds", "Time the attack was first seen", prometheus.GaugeValue, []string{"attackID", "accountName"})
+	addMetric(c.cfMetrics, "net", "attack_duration_seconds", "Time elapsed between the first and the last minute the attack was seen", prometheus.GaugeValue, []string{"attackID", "accountName"})
+	addMetric(c.cfMetrics, "net", "attack_peak_bps", "Highest bits per second rate of the attack", prometheus.GaugeValue, []string{"attackID", "accountName"})
+	addMetric(c.cfMetrics, "net", "attack_peak_pps", "Highest packets per second rate of the attack", prometheus.GaugeValue, []string{"attackID", "accountName"})
+
+	addMetric(c.cfMetrics, "magic", "firewall_bits", "Number of bits processed by Magic Firewall, labelled per rule and outcome", prometheus.GaugeValue, []string{"ruleID", "outcome", "accountName"})
+	addMetric(c.cfMetrics, "magic", "firewall_packets", "Number of packets processed by Magic Firewall, labelled per rule and outcome", prometheus.GaugeValue, []string{"ruleID", "outcome", "accountName"})
+	addMetric(c.cfMetrics, "magic", "tunnel_bits", "Number of bits sent through the tunnel, labelled per direction", prometheus.GaugeValue, []string{"tunnelName", "tunnelType", "direction", "accountName"})
+	addMetric(c.cfMetrics, "magic", "tunnel_packets", "Number of packets sent through the tunnel, labelled per direction", prometheus.GaugeValue, []string{"tunnelName", "tunnelType", "direction", "accountName"})
+	addMetric(c.cfMetrics, "magic", "tunnel_health_checks", "Number of tunnel health checks, labelled per result", prometheus.GaugeValue, []string{"tunnelName", "tunnelType", "status", "accountName"})
+	addMetric(c.cfMetrics, "magic", "prefix_bits", "Number of bits sent to the prefix, labelled per outcome", prometheus.GaugeValue, []string{"prefix", "prefixName", "outcome", "accountName"})
+	addMetric(c.cfMetrics, "magic", "prefix_packets", "Number of packets sent to the prefix, labelled per outcome", prometheus.GaugeValue, []string{"prefix", "prefixName", "outcome", "accountName"})
+
+	addMetric(c.cfMetrics, "waf", "events", "Cloudflare WAF Hits", prometheus.GaugeValue, []string{"as", "country", "action", "ruleID", "zoneName", "source", "host", "ruleDescription"})
+
+	addMetric(c.cfMetrics, "bots", "requests_by_score_bucket", "The total number of requests, labelled per bot score bucket", prometheus.GaugeValue, []string{"bucket", "zoneName"})
+	addMetric(c.cfMetrics, "bots", "requests_by_verified_category", "The total number of requests, labelled per verified bot category", prometheus.GaugeValue, []string{"category", "zoneName"})
+	addMetric(c.cfMetrics, "bots", "requests_by_ja3", "The total number of requests of the top JA3 fingerprints", prometheus.GaugeValue, []string{"ja3", "zoneName"})
+	addMetric(c.cfMetrics, "bots", "requests_by_ja4", "The total number of requests of the top JA4 fingerprints", prometheus.GaugeValue, []string{"ja4", "zoneName"})
+	addMetric(c.cfMetrics, "bots", "requests_by_detection_source", "The total number of requests, labelled per bot detection source", prometheus.GaugeValue, []string{"source", "zoneName"})
+	addMetric(c.cfMetrics, "bots", "mitigated_requests", "The total number of requests challenged or blocked by bot rules", prometheus.GaugeValue, []string{"source", "action", "zoneName"})
+
+	addMetric(c.cfMetrics, "ratelimit", "rule_threshold", "Number of requests per period allowed by the rate limiting rule", prometheus.GaugeValue, []string{"ruleID", "description", "action", "zoneName"})
+	addMetric(c.cfMetrics, "ratelimit", "rule_period_seconds", "Period in which the rate limiting rule counts requests", prometheus.GaugeValue, []string{"ruleID", "description", "action", "zoneName"})
+	addMetric(c.cfMetrics, "ratelimit", "rule_triggers", "Number of times the rate limiting rule was triggered", prometheus.GaugeValue, []string{"ruleID", "description", "action", "zoneName"})
+
+	addMetric(c.cfMetrics, "media", "images_transformations", "Unique image transformations performed by Cloudflare Images", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "images_delivered", "Images delivered by Cloudflare Images", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "images_stored", "Images stored on Cloudflare Images", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "images_allowed", "Images allowed to be stored by the Cloudflare Images subscription", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "stream_minutes_delivered", "Minutes of video delivered by Cloudflare Stream", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "stream_minutes_stored", "Minutes of video stored on Cloudflare Stream", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "stream_minutes_limit", "Minutes of video allowed to be stored by the Cloudflare Stream subscription", prometheus.GaugeValue, []string{"accountName"})
+	addMetric(c.cfMetrics, "media", "stream_videos", "Videos stored on Cloudflare Stream", prometheus.GaugeValue, []string{"accountName"})
+
+	addMetric(c.cfMetrics, "spectrum", "connections", "Connections closed by the Spectrum application", prometheus.GaugeValue, []string{"appName", "protocol", "colo", "zoneName"})
+	addMetric(c.cfMetrics, "spectrum", "bytes_ingress", "Bytes received by the Spectrum application", prometheus.GaugeValue, []string{"appName", "protocol", "colo", "zoneName"})
+	addMetric(c.cfMetrics, "spectrum", "bytes_egress", "Bytes sent by the Spectrum application", prometheus.GaugeValue, []string{"appName", "protocol", "colo", "zoneName"})
+	addMetric(c.cfMetrics, "spectrum", "connection_duration_milliseconds", "Average duration of the connections of the Spectrum application", prometheus.GaugeValue, []string{"appName", "protocol", "colo", "zoneName"})
 
 	addMetric(c.cfMetrics, "http", "bytes_by_cache_status", "The total number of processed bytes labelled per cache status", prometheus.GaugeValue, []string{"cacheStatus", "method", "contentType", "country", "zoneName"})
+	addMetric(c.cfMetrics, "http", "requests_by_cache_status", "The total number of requests labelled per cache status", prometheus.GaugeValue, []string{"cacheStatus", "method", "contentType", "country", "zoneName"})
 	addMetric(c.cfMetrics, "http", "requests_by_response_code", "The total number of request, labelled per HTTP response codes", prometheus.GaugeValue, []string{"responseCode", "zoneName"})
 	addMetric(c.cfMetrics, "http", "requests_by_country", "The total number of request, labeled per Country", prometheus.GaugeValue, []string{"country", "zoneName"})
 	addMetric(c.cfMetrics, "http", "bytes_by_country", "The total number of request, labeled per Country", prometheus.GaugeValue, []string{"country", "zoneName"})
@@ -103,14 +303,50 @@ func New(apiKey, apiMail, AccountID, zoneName, dataset string) *CloudflareCollec
 	addMetric(c.cfMetrics, "http", "total_requests", "The total number of requests served", prometheus.GaugeValue, []string{"zoneName"})
 	addMetric(c.cfMetrics, "http", "cached_requests", "The total number of requests cached", prometheus.GaugeValue, []string{"zoneName"})
 	addMetric(c.cfMetrics, "http", "encrypted_requests", "The total number of requests encrypted", prometheus.GaugeValue, []string{"zoneName"})
+	addMetric(c.cfMetrics, "http", "edge_ttfb_milliseconds", "Time to first byte measured at the edge, labelled per quantile", prometheus.GaugeValue, []string{"zoneName", "quantile"})
+	addMetric(c.cfMetrics, "http", "origin_response_duration_milliseconds", "Time spent waiting for the origin response, labelled per quantile", prometheus.GaugeValue, []string{"zoneName", "quantile"})
 
-	addMetric(c.cfMetrics, "dns", "total_queries", "DNS query count", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "uncached_queries", "DNS uncached query count", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "staled_queries", "DNS statled queryy count", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "average_response_milliseconds", "DNS average response time", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "median_response_milliseconds", "DNS median response time", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "90th_response_milliseconds", "DNS 90th percentile response time", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
-	addMetric(c.cfMetrics, "dns", "99th__response_milliseconds", "DNS 99th percentile response time", prometheus.GaugeValue, []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
+	dnsLabels := []string{"zoneName", "queryName", "queryType", "responseCode", "responseCached", "coloName", "ipVersion", "protocol", "edns"}
+	addMetricWithKey(c.cfMetrics, "dns_total_queries", "dns", "total_queries", "DNS query count", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_uncached_queries", "dns", "uncached_queries", "DNS uncached query count", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_staled_queries", "dns", "staled_queries", "DNS staled query count, always 0 as the analytics API does not report stale answers for zones", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_average_response_milliseconds", "dns", "average_response_milliseconds", "DNS average response time", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_median_response_milliseconds", "dns", "median_response_milliseconds", "DNS median response time", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_90th_response_milliseconds", "dns", "90th_response_milliseconds", "DNS 90th percentile response time", prometheus.GaugeValue, dnsLabels)
+	addMetricWithKey(c.cfMetrics, "dns_99th__response_milliseconds", "dns", "99th__response_milliseconds", "DNS 99th percentile response time", prometheus.GaugeValue, dnsLabels)
+
+	addMetric(c.cfMetrics, "cache", "requests_by_tier", "The total number of requests, labelled per cache tier and status", prometheus.GaugeValue, []string{"tier", "cacheStatus", "zoneName"})
+	addMetric(c.cfMetrics, "cache", "bytes_by_tier", "The total number of bytes, labelled per cache tier and status", prometheus.GaugeValue, []string{"tier", "cacheStatus", "zoneName"})
+	addMetric(c.cfMetrics, "cache", "hit_ratio", "Share of the requests served from cache, labelled per host", prometheus.GaugeValue, []string{"host", "zoneName"})
+	addMetric(c.cfMetrics, "cache", "reserve_operations", "Cache Reserve operations, labelled per operation class", prometheus.GaugeValue, []string{"operationClass", "zoneName"})
+	addMetric(c.cfMetrics, "cache", "reserve_stored_bytes", "Bytes stored on Cache Reserve", prometheus.GaugeValue, []string{"zoneName"})
+	addMetric(c.cfMetrics, "cache", "reserve_objects", "Objects stored on Cache Reserve", prometheus.GaugeValue, []string{"zoneName"})
+
+	addMetric(c.cfMetrics, "dns", "records", "Number of DNS records, labelled per type and proxied flag", prometheus.GaugeValue, []string{"zoneName", "type", "proxied"})
+	addMetric(c.cfMetrics, "dns", "record_ttl", "TTL of the DNS record, 1 means automatic", prometheus.GaugeValue, []string{"zoneName", "name", "type", "content"})
+	addMetric(c.cfMetrics, "dns", "record_drift", "DNS records that do not match the desired state", prometheus.GaugeValue, []string{"zoneName", "name", "type", "content", "drift"})
+
+	addMetric(c.cfMetrics, "ssl", "certificate_expiry_timestamp_seconds", "Time the certificate expires", prometheus.GaugeValue, []string{"zoneName", "certificateID", "source", "hosts", "issuer"})
+	addMetric(c.cfMetrics, "ssl", "certificate_status", "Validation status of the certificate", prometheus.GaugeValue, []string{"zoneName", "certificateID", "source", "status"})
+
+	addMetric(c.cfMetrics, "zone", "info", "Information about the zone", prometheus.GaugeValue, []string{"zoneName", "zoneID", "plan", "status", "paused", "nameServers", "accountID", "accountName"})
+	addMetric(c.cfMetrics, "zone", "activated_timestamp_seconds", "Time the zone was activated", prometheus.GaugeValue, []string{"zoneName"})
+	addMetric(c.cfMetrics, "zone", "count_by_plan", "Number of zones, labelled per plan", prometheus.GaugeValue, []string{"plan"})
+	addMetric(c.cfMetrics, "zone", "setting", "Value of the zone setting", prometheus.GaugeValue, []string{"zoneName", "setting", "value"})
+	addMetric(c.cfMetrics, "zone", "compliance", "Whether the zone settings pass the policy rule", prometheus.GaugeValue, []string{"zoneName", "rule"})
+
+	addMetric(c.cfMetrics, "logpush", "job_enabled", "Whether the Logpush job is enabled", prometheus.GaugeValue, []string{"jobID", "jobName", "dataset", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "logpush", "job_last_complete_timestamp_seconds", "Time the Logpush job last pushed a batch of logs", prometheus.GaugeValue, []string{"jobID", "jobName", "dataset", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "logpush", "job_last_error_timestamp_seconds", "Time the Logpush job last failed", prometheus.GaugeValue, []string{"jobID", "jobName", "dataset", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "logpush", "job_error", "Whether the Logpush job reports an error message", prometheus.GaugeValue, []string{"jobID", "jobName", "dataset", "zoneName", "accountName"})
+
+	addMetric(c.cfMetrics, "audit", "actions", "Actions recorded on the audit log since the exporter started, labelled per actor, resource type and action", prometheus.CounterValue, []string{"actor", "resourceType", "action", "accountName"})
+
+	addMetric(c.cfMetrics, "billing", "subscription_price", "Price of the subscription", prometheus.GaugeValue, []string{"subscriptionID", "product", "currency", "frequency", "state", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "billing", "subscription_renewal_timestamp_seconds", "Time the current period of the subscription ends", prometheus.GaugeValue, []string{"subscriptionID", "product", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "billing", "subscription_component_quantity", "Quantity of the component assigned to the subscription", prometheus.GaugeValue, []string{"subscriptionID", "product", "component", "zoneName", "accountName"})
+	addMetric(c.cfMetrics, "billing", "usage_consumed_quantity", "Quantity consumed by the usage-based product since the billing period started, reset every month", prometheus.CounterValue, []string{"service", "unit", "accountName"})
+	addMetric(c.cfMetrics, "billing", "usage_cost", "Cost of the usage-based product since the billing period started, reset every month", prometheus.CounterValue, []string{"service", "currency", "accountName"})
 
 	addMetric(c.cfMetrics, "vdns", "total_queries", "DNS query count", prometheus.GaugeValue, []string{"clusterName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
 	addMetric(c.cfMetrics, "vdns", "uncached_queries", "DNS uncached query count", prometheus.GaugeValue, []string{"clusterName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
@@ -120,7 +356,7 @@ func New(apiKey, apiMail, AccountID, zoneName, dataset string) *CloudflareCollec
 	addMetric(c.cfMetrics, "vdns", "90th_response_milliseconds", "DNS 90th percentile response time", prometheus.GaugeValue, []string{"clusterName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
 	addMetric(c.cfMetrics, "vdns", "99th__response_milliseconds", "DNS 99th percentile response time", prometheus.GaugeValue, []string{"clusterName", "queryName", "queryType", "responseCode", "responseCached", "coloName"})
 
-	err := c.Validate()
+	err = c.Validate()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -155,9 +391,52 @@ func (collector *CloudflareCollector) Validate() error {
 	if contains(collector.dataset, "net") && collector.accountID == "" {
 		return errors.New("You must provide an accountID when exporting network analytics")
 	}
+	// Attacks are last seen at least queryLag ago, and those that ended are still on the next windows
+	if contains(collector.dataset, "net") && collector.attackQuietPeriod < queryWindow+queryLag {
+		return errors.New("The attack quiet period must be at least " + (queryWindow + queryLag).String())
+	}
+	if contains(collector.dataset, "magic") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Magic Transit analytics")
+	}
 	if contains(collector.dataset, "workers") && collector.accountID == "" {
 		return errors.New("You must provide an accountID when exporting worker analytics")
 	}
+	if contains(collector.dataset, "cron") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting cron trigger analytics")
+	}
+	if contains(collector.dataset, "pages") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Pages Functions analytics")
+	}
+	if contains(collector.dataset, "tunnels") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting tunnel status")
+	}
+	if contains(collector.dataset, "gateway") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Gateway analytics")
+	}
+	if contains(collector.dataset, "access") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Access analytics")
+	}
+	if contains(collector.dataset, "rum") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Web Analytics")
+	}
+	if contains(collector.dataset, "storage") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting storage analytics")
+	}
+	if contains(collector.dataset, "d1") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting D1 analytics")
+	}
+	if contains(collector.dataset, "queues") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Queues analytics")
+	}
+	if contains(collector.dataset, "media") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting Images and Stream usage")
+	}
+	if contains(collector.dataset, "billing") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting billing subscriptions")
+	}
+	if contains(collector.dataset, "audit") && collector.accountID == "" {
+		return errors.New("You must provide an accountID when exporting audit logs")
+	}
 	return nil
 }
 
@@ -179,30 +458,162 @@ func (collector *CloudflareCollector) Collect(ch chan<- prometheus.Metric) {
 			log.Println(err)
 		}
 	}
+	if contains(collector.dataset, "magic") {
+		err = collector.collectMagicTransit(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	if contains(collector.dataset, "http") {
 		err = collector.collectHTTP(ch)
 		if err != nil {
 			log.Println(err)
 		}
 	}
+	if contains(collector.dataset, "cache") {
+		err = collector.collectCache(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	if contains(collector.dataset, "waf") {
 		err = collector.collectWAF(ch)
 		if err != nil {
 			log.Println(err)
 		}
 	}
+	if contains(collector.dataset, "bots") {
+		err = collector.collectBots(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "ratelimit") {
+		err = collector.collectRateLimit(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "spectrum") {
+		err = collector.collectSpectrum(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	if contains(collector.dataset, "workers") {
 		err = collector.collectWorkers(ch)
 		if err != nil {
 			log.Println(err)
 		}
 	}
+	if contains(collector.dataset, "cron") {
+		err = collector.collectCron(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "pages") {
+		err = collector.collectPages(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "tunnels") {
+		err = collector.collectTunnels(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "gateway") {
+		err = collector.collectGateway(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "access") {
+		err = collector.collectAccess(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "rum") {
+		err = collector.collectRUM(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "storage") {
+		err = collector.collectStorage(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "d1") {
+		err = collector.collectD1(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "queues") {
+		err = collector.collectQueues(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "media") {
+		err = collector.collectMedia(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	if contains(collector.dataset, "dns") {
 		err = collector.collectDNS(ch)
 		if err != nil {
 			log.Println(err)
 		}
 	}
+	if contains(collector.dataset, "dnsrecords") {
+		err = collector.collectDNSRecords(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "ssl") {
+		err = collector.collectCertificates(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "zones") {
+		err = collector.collectZones(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "settings") {
+		err = collector.collectZoneSettings(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "billing") {
+		err = collector.collectBilling(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "audit") {
+		err = collector.collectAuditLogs(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if contains(collector.dataset, "logpush") {
+		err = collector.collectLogpush(ch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
 	if contains(collector.dataset, "vdns") {
 		err = collector.collectDNSFirewall(ch)
 		if err != nil {
@@ -212,8 +623,8 @@ func (collector *CloudflareCollector) Collect(ch chan<- prometheus.Metric) {
 }
 
 func (collector *CloudflareCollector) login() error {
-	collector.startDate = time.Now().Add(time.Duration(-20) * time.Minute).Format(time.RFC3339)
-	collector.endDate = time.Now().Add(time.Duration(-5) * time.Minute).Format(time.RFC3339)
+	collector.startDate = time.Now().Add(-(queryWindow + queryLag)).Format(time.RFC3339)
+	collector.endDate = time.Now().Add(-queryLag).Format(time.RFC3339)
 
 	var err error
 	collector.API, err = cloudflare.New(collector.apiKey, collector.apiEmail)
@@ -238,27 +649,31 @@ func (collector *CloudflareCollector) collectDNS(ch chan<- prometheus.Metric) er
 		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
 			continue
 		}
-		if zone.Name != "" && zone.Name != collector.zoneName {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
 			continue
 		}
 		log.Printf("Getting DNS metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
-		resp, err := getCloudflareDNSMetrics(zone.ID, collector.apiEmail, collector.apiKey, buildDNSQueryOptions(collector.startDate, collector.endDate))
+		resp, err := getCloudflareDNSMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no DNS analytics returned for " + zone.Name)
+		}
 		if err == nil {
-			for _, node := range resp.Data {
-				ch <- collector.updateMetric("total_queries", node.Metrics[0],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("uncached_queries", node.Metrics[1],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("staled_queries", node.Metrics[2],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("average_response_milliseconds", node.Metrics[3],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("median_response_milliseconds", node.Metrics[4],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("90th_response_milliseconds", node.Metrics[5],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("99th__response_milliseconds", node.Metrics[6],
-					zone.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+			for _, node := range resp.Viewer.Zones[0].DNSQueries {
+				d := node.Dimensions
+				cached := "uncached"
+				uncached := float64(node.Count)
+				if d.ResponseCached == 1 {
+					cached = "cached"
+					uncached = 0
+				}
+				labels := []string{zone.Name, d.QueryName, d.QueryType, d.ResponseCode, cached, d.ColoName, strconv.Itoa(d.IPVersion), d.Protocol, strconv.Itoa(d.QueryEDNS)}
+				ch <- collector.updateMetric("dns_total_queries", float64(node.Count), labels...)
+				ch <- collector.updateMetric("dns_uncached_queries", uncached, labels...)
+				ch <- collector.updateMetric("dns_staled_queries", 0, labels...)
+				ch <- collector.updateMetric("dns_average_response_milliseconds", node.Avg.ResponseTime, labels...)
+				ch <- collector.updateMetric("dns_median_response_milliseconds", node.Quantiles.ResponseTimeP50, labels...)
+				ch <- collector.updateMetric("dns_90th_response_milliseconds", node.Quantiles.ResponseTimeP90, labels...)
+				ch <- collector.updateMetric("dns_99th__response_milliseconds", node.Quantiles.ResponseTimeP99, labels...)
 			}
 		} else {
 			log.Println("Fetch failed :", err)
@@ -267,85 +682,417 @@ func (collector *CloudflareCollector) collectDNS(ch chan<- prometheus.Metric) er
 	return nil
 }
 
-func (collector *CloudflareCollector) collectDNSFirewall(ch chan<- prometheus.Metric) error {
-	vDNSList, err := collector.API.ListVirtualDNS()
-	if err != nil {
-		return err
+func (collector *CloudflareCollector) collectDNSRecords(ch chan<- prometheus.Metric) error {
+	var desired DesiredState
+	var err error
+	if collector.dnsDesiredState != "" {
+		desired, err = loadDesiredState(collector.dnsDesiredState)
+		if err != nil {
+			log.Println("Unable to load the DNS desired state :", err)
+		}
 	}
-	for _, vdns := range vDNSList {
-		log.Printf("Getting vDNS metrics for %s from %s to %s \n", vdns.Name, collector.startDate, collector.endDate)
-		resp, err := getCloudflareDNSFirewallMetrics(collector.accountID, vdns.ID, collector.apiEmail, collector.apiKey, buildDNSQueryOptions(collector.startDate, collector.endDate))
-		if err == nil {
-			for _, node := range resp.Data {
-				ch <- collector.updateMetric("total_queries", node.Metrics[0],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("uncached_queries", node.Metrics[1],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("staled_queries", node.Metrics[2],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("average_response_milliseconds", node.Metrics[3],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("median_response_milliseconds", node.Metrics[4],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("90th_response_milliseconds", node.Metrics[5],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-				ch <- collector.updateMetric("99th__response_milliseconds", node.Metrics[6],
-					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
-			}
-		} else {
+	for _, zone := range collector.zones {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting DNS records for %s\n", zone.Name)
+		records, err := collector.API.DNSRecords(zone.ID, cloudflare.DNSRecord{})
+		if err != nil {
 			log.Println("Fetch failed :", err)
+			continue
+		}
+		type typeProxied struct {
+			rtype   string
+			proxied bool
+		}
+		counts := make(map[typeProxied]int)
+		for _, record := range records {
+			counts[typeProxied{record.Type, record.Proxied}]++
+			ch <- collector.updateMetric("record_ttl", float64(record.TTL), zone.Name, record.Name, record.Type, record.Content)
+		}
+		for key, count := range counts {
+			ch <- collector.updateMetric("records", float64(count), zone.Name, key.rtype, strconv.FormatBool(key.proxied))
+		}
+		if !contains(desired.zones(), zone.Name) {
+			continue
+		}
+		for _, drift := range recordDrift(desired, zone.Name, records) {
+			ch <- collector.updateMetric("record_drift", 1, zone.Name, drift.Name, drift.Type, drift.Content, drift.Drift)
 		}
 	}
-
 	return nil
 }
 
-func (collector *CloudflareCollector) collectHTTP(ch chan<- prometheus.Metric) error {
+func (collector *CloudflareCollector) collectCertificates(ch chan<- prometheus.Metric) error {
 	for _, zone := range collector.zones {
-		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
 			continue
 		}
-		if zone.Name != "" && zone.Name != collector.zoneName {
-			continue
+		log.Printf("Getting certificates for %s\n", zone.Name)
+		certificates, err := getCloudflareCertificates(zone.ID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
 		}
-		log.Printf("Getting HTTP metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
-		resp, err := getCloudflareHTTPMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
-		if err == nil {
-			for _, node := range resp.Viewer.Zones[0].Caching {
-				ch <- collector.updateMetric("bytes_by_cache_status", float64(node.SumEdgeResponseBytes.EdgeResponseBytes),
-					node.Dimensions.CacheStatus, node.Dimensions.HTTPMethod, node.Dimensions.ContentTypeName, node.Dimensions.CountryName, zone.Name)
-			}
-
-			RequestsData := resp.Viewer.Zones[0].Requests[0].RequestsData
-
-			ch <- collector.updateMetric("total_bytes", float64(RequestsData.Bytes), zone.Name)
-			ch <- collector.updateMetric("cached_bytes", float64(RequestsData.CachedBytes), zone.Name)
-			ch <- collector.updateMetric("encrypted_bytes", float64(RequestsData.EncryptedBytes), zone.Name)
-			ch <- collector.updateMetric("total_requests", float64(RequestsData.Requests), zone.Name)
-			ch <- collector.updateMetric("cached_requests", float64(RequestsData.CachedRequests), zone.Name)
-			ch <- collector.updateMetric("encrypted_requests", float64(RequestsData.EncryptedRequests), zone.Name)
-
-			for _, node := range RequestsData.ResponseStatusMap {
-				ch <- collector.updateMetric("requests_by_response_code", float64(node.Requests), strconv.Itoa(node.EdgeResponseStatus), zone.Name)
-			}
-
-			for _, node := range RequestsData.CountryMap {
-				ch <- collector.updateMetric("requests_by_country", float64(node.Requests), node.CountryName, zone.Name)
-				ch <- collector.updateMetric("bytes_by_country", float64(node.Bytes), node.CountryName, zone.Name)
-				ch <- collector.updateMetric("threats_by_country", float64(node.Threats), node.CountryName, zone.Name)
-			}
-			for _, node := range RequestsData.ContentTypeMap {
-				ch <- collector.updateMetric("requests_by_content_type", float64(node.Requests), node.ContentTypeName, zone.Name)
-				ch <- collector.updateMetric("bytes_by_content_type", float64(node.Bytes), node.ContentTypeName, zone.Name)
+		for _, certificate := range certificates {
+			if !certificate.ExpiresOn.IsZero() {
+				ch <- collector.updateMetric("certificate_expiry_timestamp_seconds", float64(certificate.ExpiresOn.Unix()),
+					zone.Name, certificate.ID, certificate.Source, certificate.Hosts, certificate.Issuer)
 			}
-			for _, node := range RequestsData.ClientSSLMap {
-				ch <- collector.updateMetric("requests_by_ssl_version", float64(node.Requests), node.ClientSSLProtocol, zone.Name)
-			}
-			for _, node := range RequestsData.ClientHTTPVersionMap {
-				ch <- collector.updateMetric("requests_by_http_version", float64(node.Requests), node.ClientHTTPProtocol, zone.Name)
-			}
-		} else {
-			log.Println("Fetch failed :", err)
+			ch <- collector.updateMetric("certificate_status", 1, zone.Name, certificate.ID, certificate.Source, certificate.Status)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectZones(ch chan<- prometheus.Metric) error {
+	if collector.activations.stale(collector.zones, time.Now()) {
+		activations, err := getCloudflareZoneActivations(collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Unable to fetch zone activations :", err)
+		} else {
+			collector.activations = zoneActivations{activated: activations, updated: time.Now()}
+		}
+	}
+	plans := make(map[string]int)
+	for _, zone := range collector.zones {
+		plans[zone.Plan.ZonePlanCommon.Name]++
+		ch <- collector.updateMetric("info", 1, zone.Name, zone.ID, zone.Plan.ZonePlanCommon.Name, zone.Status, strconv.FormatBool(zone.Paused),
+			strings.Join(zone.NameServers, ","), zone.Account.ID, zone.Account.Name)
+		if activated := collector.activations.activated[zone.ID]; !activated.IsZero() {
+			ch <- collector.updateMetric("activated_timestamp_seconds", float64(activated.Unix()), zone.Name)
+		}
+	}
+	for plan, count := range plans {
+		ch <- collector.updateMetric("count_by_plan", float64(count), plan)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectLogpush(ch chan<- prometheus.Metric) error {
+	if collector.accountID != "" {
+		log.Printf("Getting Logpush jobs for %s\n", collector.accountID)
+		jobs, err := getCloudflareLogpushJobs("accounts", collector.accountID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+		}
+		for _, job := range jobs {
+			collector.collectLogpushJob(ch, job, "")
+		}
+	}
+	for _, zone := range collector.zones {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting Logpush jobs for %s\n", zone.Name)
+		jobs, err := getCloudflareLogpushJobs("zones", zone.ID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		for _, job := range jobs {
+			collector.collectLogpushJob(ch, job, zone.Name)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectLogpushJob(ch chan<- prometheus.Metric, job LogpushJob, zoneName string) {
+	enabled, failing := 0.0, 0.0
+	if job.Enabled {
+		enabled = 1
+	}
+	if job.ErrorMessage != "" {
+		failing = 1
+	}
+	ch <- collector.updateMetric("job_enabled", enabled, job.jobID(), job.Name, job.Dataset, zoneName, collector.account.Name)
+	ch <- collector.updateMetric("job_error", failing, job.jobID(), job.Name, job.Dataset, zoneName, collector.account.Name)
+	if !job.LastComplete.IsZero() {
+		ch <- collector.updateMetric("job_last_complete_timestamp_seconds", float64(job.LastComplete.Unix()),
+			job.jobID(), job.Name, job.Dataset, zoneName, collector.account.Name)
+	}
+	if !job.LastError.IsZero() {
+		ch <- collector.updateMetric("job_last_error_timestamp_seconds", float64(job.LastError.Unix()),
+			job.jobID(), job.Name, job.Dataset, zoneName, collector.account.Name)
+	}
+}
+
+func (collector *CloudflareCollector) collectAuditLogs(ch chan<- prometheus.Metric) error {
+	log.Printf("Getting audit logs for %s since %s\n", collector.accountID, collector.auditCursor.Since.Format(time.RFC3339))
+	events, err := getCloudflareAuditLogs(collector.accountID, collector.auditCursor.Since, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch failed :", err)
+	}
+	var output io.WriteCloser
+	if collector.auditOutput != "" && len(events) > 0 {
+		output, err = openAuditOutput(collector.auditOutput)
+		if err != nil {
+			log.Println("Unable to open the audit log output :", err)
+		} else {
+			defer output.Close()
+		}
+	}
+	for _, event := range events {
+		if !collector.auditCursor.accept(event.ID, event.When) {
+			continue
+		}
+		collector.auditCounts[auditKey{event.Actor.name(), event.Resource.Type, event.Action.Type}]++
+		if output != nil {
+			err = writeAuditEvent(output, event)
+			if err != nil {
+				log.Println("Unable to forward the audit log event :", err)
+			}
+		}
+	}
+	err = collector.auditCursor.save(collector.auditStateFile)
+	if err != nil {
+		log.Println("Unable to save the audit log cursor :", err)
+	}
+	for key, count := range collector.auditCounts {
+		ch <- collector.updateMetric("actions", count, key.actor, key.resourceType, key.action, collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectBilling(ch chan<- prometheus.Metric) error {
+	log.Printf("Getting subscriptions for %s\n", collector.accountID)
+	subscriptions, err := getCloudflareAccountSubscriptions(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch account subscriptions :", err)
+	}
+	for _, subscription := range subscriptions {
+		collector.collectSubscription(ch, subscription, "")
+	}
+	usage, err := getCloudflareUsage(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch account usage :", err)
+	}
+	quantities, costs := sumUsage(usage)
+	for key, quantity := range quantities {
+		ch <- collector.updateMetric("usage_consumed_quantity", quantity, key.service, key.unit, collector.account.Name)
+	}
+	for key, cost := range costs {
+		ch <- collector.updateMetric("usage_cost", cost, key.service, key.unit, collector.account.Name)
+	}
+	for _, zone := range collector.zones {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		subscription, err := getCloudflareZoneSubscription(zone.ID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		collector.collectSubscription(ch, subscription, zone.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectSubscription(ch chan<- prometheus.Metric, subscription Subscription, zoneName string) {
+	product := subscription.productName()
+	ch <- collector.updateMetric("subscription_price", subscription.Price, subscription.ID, product,
+		subscription.Currency, subscription.Frequency, subscription.State, zoneName, collector.account.Name)
+	if !subscription.CurrentPeriodEnd.IsZero() {
+		ch <- collector.updateMetric("subscription_renewal_timestamp_seconds", float64(subscription.CurrentPeriodEnd.Unix()),
+			subscription.ID, product, zoneName, collector.account.Name)
+	}
+	for _, component := range subscription.ComponentValues {
+		ch <- collector.updateMetric("subscription_component_quantity", component.Value, subscription.ID, product, component.Name, zoneName, collector.account.Name)
+	}
+}
+
+func (collector *CloudflareCollector) collectZoneSettings(ch chan<- prometheus.Metric) error {
+	var policy Policy
+	var err error
+	if collector.compliancePolicy != "" {
+		policy, err = loadPolicy(collector.compliancePolicy)
+		if err != nil {
+			log.Println("Unable to load the compliance policy :", err)
+		}
+	}
+	for _, zone := range collector.zones {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting zone settings for %s\n", zone.Name)
+		resp, err := collector.API.ZoneSettings(zone.ID)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		settings := flattenSettings(resp.Result)
+		for setting, value := range settings {
+			ch <- collector.updateMetric("setting", 1, zone.Name, setting, value)
+		}
+		for _, rule := range policy.Rules {
+			passes := 0.0
+			if rule.passes(settings) {
+				passes = 1
+			}
+			ch <- collector.updateMetric("compliance", passes, zone.Name, rule.Name)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectDNSFirewall(ch chan<- prometheus.Metric) error {
+	vDNSList, err := collector.API.ListVirtualDNS()
+	if err != nil {
+		return err
+	}
+	for _, vdns := range vDNSList {
+		log.Printf("Getting vDNS metrics for %s from %s to %s \n", vdns.Name, collector.startDate, collector.endDate)
+		resp, err := getCloudflareDNSFirewallMetrics(collector.accountID, vdns.ID, collector.apiEmail, collector.apiKey, buildDNSQueryOptions(collector.startDate, collector.endDate))
+		if err == nil {
+			for _, node := range resp.Data {
+				if len(node.Dimensions) < 5 || len(node.Metrics) < 7 {
+					continue
+				}
+				ch <- collector.updateMetric("total_queries", node.Metrics[0],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("uncached_queries", node.Metrics[1],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("staled_queries", node.Metrics[2],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("average_response_milliseconds", node.Metrics[3],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("median_response_milliseconds", node.Metrics[4],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("90th_response_milliseconds", node.Metrics[5],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+				ch <- collector.updateMetric("99th__response_milliseconds", node.Metrics[6],
+					vdns.Name, node.Dimensions[0], node.Dimensions[1], node.Dimensions[2], node.Dimensions[3], node.Dimensions[4])
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+	}
+
+	return nil
+}
+
+func (collector *CloudflareCollector) collectHTTP(ch chan<- prometheus.Metric) error {
+	for _, zone := range collector.zones {
+		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
+			continue
+		}
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting HTTP metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
+		resp, err := getCloudflareHTTPMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil {
+			for _, node := range resp.Viewer.Zones[0].Caching {
+				ch <- collector.updateMetric("bytes_by_cache_status", float64(node.SumEdgeResponseBytes.EdgeResponseBytes),
+					node.Dimensions.CacheStatus, node.Dimensions.HTTPMethod, node.Dimensions.ContentTypeName, node.Dimensions.CountryName, zone.Name)
+				ch <- collector.updateMetric("requests_by_cache_status", float64(node.Count),
+					node.Dimensions.CacheStatus, node.Dimensions.HTTPMethod, node.Dimensions.ContentTypeName, node.Dimensions.CountryName, zone.Name)
+			}
+
+			RequestsData := resp.Viewer.Zones[0].Requests[0].RequestsData
+
+			ch <- collector.updateMetric("total_bytes", float64(RequestsData.Bytes), zone.Name)
+			ch <- collector.updateMetric("cached_bytes", float64(RequestsData.CachedBytes), zone.Name)
+			ch <- collector.updateMetric("encrypted_bytes", float64(RequestsData.EncryptedBytes), zone.Name)
+			ch <- collector.updateMetric("total_requests", float64(RequestsData.Requests), zone.Name)
+			ch <- collector.updateMetric("cached_requests", float64(RequestsData.CachedRequests), zone.Name)
+			ch <- collector.updateMetric("encrypted_requests", float64(RequestsData.EncryptedRequests), zone.Name)
+
+			for _, node := range RequestsData.ResponseStatusMap {
+				ch <- collector.updateMetric("requests_by_response_code", float64(node.Requests), strconv.Itoa(node.EdgeResponseStatus), zone.Name)
+			}
+
+			for _, node := range RequestsData.CountryMap {
+				ch <- collector.updateMetric("requests_by_country", float64(node.Requests), node.CountryName, zone.Name)
+				ch <- collector.updateMetric("bytes_by_country", float64(node.Bytes), node.CountryName, zone.Name)
+				ch <- collector.updateMetric("threats_by_country", float64(node.Threats), node.CountryName, zone.Name)
+			}
+			for _, node := range RequestsData.ContentTypeMap {
+				ch <- collector.updateMetric("requests_by_content_type", float64(node.Requests), node.ContentTypeName, zone.Name)
+				ch <- collector.updateMetric("bytes_by_content_type", float64(node.Bytes), node.ContentTypeName, zone.Name)
+			}
+			for _, node := range RequestsData.ClientSSLMap {
+				ch <- collector.updateMetric("requests_by_ssl_version", float64(node.Requests), node.ClientSSLProtocol, zone.Name)
+			}
+			for _, node := range RequestsData.ClientHTTPVersionMap {
+				ch <- collector.updateMetric("requests_by_http_version", float64(node.Requests), node.ClientHTTPProtocol, zone.Name)
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+
+		resp, err = getCloudflareHTTPLatencyMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no latency analytics returned for " + zone.Name)
+		}
+		if err == nil {
+			for _, node := range resp.Viewer.Zones[0].Latency {
+				for _, q := range collector.latencyQuantiles {
+					quantile := strconv.FormatFloat(q, 'f', -1, 64)
+					ch <- collector.updateMetric("edge_ttfb_milliseconds", node.Quantiles["edgeTimeToFirstByteMs"+quantileSuffix(q)], zone.Name, quantile)
+					ch <- collector.updateMetric("origin_response_duration_milliseconds", node.Quantiles["originResponseDurationMs"+quantileSuffix(q)], zone.Name, quantile)
+				}
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectCache(ch chan<- prometheus.Metric) error {
+	for _, zone := range collector.zones {
+		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
+			continue
+		}
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting Cache metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
+		resp, err := getCloudflareCacheMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no Cache analytics returned for " + zone.Name)
+		}
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		type tierStatus struct {
+			tier   string
+			status string
+		}
+		tiers := make(map[tierStatus]CacheGroup)
+		for _, node := range resp.Viewer.Zones[0].CacheTiers {
+			key := tierStatus{cacheTier(node.Dimensions.UpperTierColoName), node.Dimensions.CacheStatus}
+			group := tiers[key]
+			group.Count += node.Count
+			group.Sum.EdgeResponseBytes += node.Sum.EdgeResponseBytes
+			tiers[key] = group
+		}
+		for key, group := range tiers {
+			ch <- collector.updateMetric("requests_by_tier", float64(group.Count), key.tier, key.status, zone.Name)
+			ch <- collector.updateMetric("bytes_by_tier", float64(group.Sum.EdgeResponseBytes), key.tier, key.status, zone.Name)
+		}
+
+		hits := make(map[string]int)
+		totals := make(map[string]int)
+		for _, node := range resp.Viewer.Zones[0].CacheHosts {
+			totals[node.Dimensions.Host] += node.Count
+			if contains(cacheHitStatuses, node.Dimensions.CacheStatus) {
+				hits[node.Dimensions.Host] += node.Count
+			}
+		}
+		for host, total := range totals {
+			if total > 0 {
+				ch <- collector.updateMetric("hit_ratio", float64(hits[host])/float64(total), host, zone.Name)
+			}
+		}
+
+		for _, node := range resp.Viewer.Zones[0].CacheReserveRequests {
+			ch <- collector.updateMetric("reserve_operations", float64(node.Sum.Requests), node.Dimensions.OperationClass, zone.Name)
+		}
+		for _, node := range resp.Viewer.Zones[0].CacheReserveStorage {
+			ch <- collector.updateMetric("reserve_stored_bytes", float64(node.Max.StoredBytes), zone.Name)
+			ch <- collector.updateMetric("reserve_objects", float64(node.Max.ObjectCount), zone.Name)
 		}
 	}
 	return nil
@@ -356,7 +1103,7 @@ func (collector *CloudflareCollector) collectWAF(ch chan<- prometheus.Metric) er
 		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
 			continue
 		}
-		if zone.Name != "" && zone.Name != collector.zoneName {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
 			continue
 		}
 		log.Printf("Getting WAF metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
@@ -365,9 +1112,146 @@ func (collector *CloudflareCollector) collectWAF(ch chan<- prometheus.Metric) er
 			collector.apiEmail,
 			collector.apiKey,
 		)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no firewall events returned for " + zone.Name)
+		}
 		if err == nil {
+			descriptions := collector.ruleDescriptions(zone.ID)
 			for _, node := range resp.Viewer.Zones[0].FwEvents {
-				ch <- collector.updateMetric("events", float64(node.Count), node.Dimensions.ASName, node.Dimensions.Country, node.Dimensions.Action, node.Dimensions.RuleID, zone.Name)
+				ch <- collector.updateMetric("events", float64(node.Count), node.Dimensions.ASName, node.Dimensions.Country, node.Dimensions.Action, node.Dimensions.RuleID, zone.Name,
+					node.Dimensions.Source, node.Dimensions.Host, descriptions[node.Dimensions.RuleID])
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+	}
+	return nil
+}
+
+// ruleDescriptions returns the cached rule descriptions of a zone, refreshing them once they are older than rulesetRefresh
+func (collector *CloudflareCollector) ruleDescriptions(zoneID string) map[string]string {
+	cached, ok := collector.rules[zoneID]
+	if ok && time.Since(cached.updated) < collector.rulesetRefresh {
+		return cached.descriptions
+	}
+	descriptions, err := getCloudflareRuleDescriptions(zoneID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		// Keep the previous descriptions and wait for the next refresh instead of retrying on every scrape
+		log.Println("Unable to fetch rulesets :", err)
+		collector.rules[zoneID] = ruleDescriptions{descriptions: cached.descriptions, updated: time.Now()}
+		return cached.descriptions
+	}
+	collector.rules[zoneID] = ruleDescriptions{descriptions: descriptions, updated: time.Now()}
+	return descriptions
+}
+
+func (collector *CloudflareCollector) collectBots(ch chan<- prometheus.Metric) error {
+	for _, zone := range collector.zones {
+		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
+			continue
+		}
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting Bot metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
+		resp, err := getCloudflareBotsMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no bot analytics returned for " + zone.Name)
+		}
+		if err == nil {
+			buckets := make(map[string]int)
+			for _, node := range resp.Viewer.Zones[0].BotScores {
+				buckets[botScoreBucket(node.Dimensions.BotScore)] += node.Count
+			}
+			for bucket, count := range buckets {
+				ch <- collector.updateMetric("requests_by_score_bucket", float64(count), bucket, zone.Name)
+			}
+			for _, node := range resp.Viewer.Zones[0].BotCategories {
+				ch <- collector.updateMetric("requests_by_verified_category", float64(node.Count), node.Dimensions.VerifiedBotCategory, zone.Name)
+			}
+			for _, node := range resp.Viewer.Zones[0].BotJA3 {
+				ch <- collector.updateMetric("requests_by_ja3", float64(node.Count), node.Dimensions.JA3Hash, zone.Name)
+			}
+			for _, node := range resp.Viewer.Zones[0].BotJA4 {
+				ch <- collector.updateMetric("requests_by_ja4", float64(node.Count), node.Dimensions.JA4, zone.Name)
+			}
+			for _, node := range resp.Viewer.Zones[0].BotSources {
+				ch <- collector.updateMetric("requests_by_detection_source", float64(node.Count), node.Dimensions.BotScoreSrcName, zone.Name)
+			}
+			for _, node := range resp.Viewer.Zones[0].BotActions {
+				ch <- collector.updateMetric("mitigated_requests", float64(node.Count), node.Dimensions.Source, node.Dimensions.Action, zone.Name)
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectRateLimit(ch chan<- prometheus.Metric) error {
+	for _, zone := range collector.zones {
+		if zone.Plan.ZonePlanCommon.Name != "Enterprise Website" {
+			continue
+		}
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting Rate Limiting metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
+		rules, err := getCloudflareRateLimitRules(zone.ID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		triggers := make(map[string]int)
+		resp, err := getCloudflareRateLimitMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil && len(resp.Viewer.Zones) == 0 {
+			err = errors.New("no rate limiting analytics returned for " + zone.Name)
+		}
+		if err == nil {
+			for _, node := range resp.Viewer.Zones[0].RateLimitEvents {
+				triggers[node.Dimensions.RuleID] += node.Count
+			}
+		} else {
+			log.Println("Fetch failed :", err)
+		}
+		for _, rule := range rules {
+			if !rule.Enabled {
+				continue
+			}
+			ch <- collector.updateMetric("rule_threshold", float64(rule.RateLimit.RequestsPerPeriod), rule.ID, rule.Description, rule.Action, zone.Name)
+			ch <- collector.updateMetric("rule_period_seconds", float64(rule.RateLimit.Period), rule.ID, rule.Description, rule.Action, zone.Name)
+			ch <- collector.updateMetric("rule_triggers", float64(triggers[rule.ID]), rule.ID, rule.Description, rule.Action, zone.Name)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectSpectrum(ch chan<- prometheus.Metric) error {
+	for _, zone := range collector.zones {
+		if collector.zoneName != "" && zone.Name != collector.zoneName {
+			continue
+		}
+		log.Printf("Getting Spectrum metrics for %s from %s to %s \n", zone.Name, collector.startDate, collector.endDate)
+		apps, err := getCloudflareSpectrumApps(zone.ID, collector.apiEmail, collector.apiKey)
+		if err != nil {
+			log.Println("Fetch failed :", err)
+			continue
+		}
+		if len(apps) == 0 {
+			continue
+		}
+		events, err := getCloudflareSpectrumMetrics(collector.startDate, collector.endDate, zone.ID, collector.apiEmail, collector.apiKey)
+		if err == nil {
+			for _, node := range events {
+				app, ok := apps[node.AppID]
+				name := app.DNS.Name
+				if !ok {
+					name = node.AppID
+				}
+				ch <- collector.updateMetric("connections", node.Connections, name, app.Protocol, node.Colo, zone.Name)
+				ch <- collector.updateMetric("bytes_ingress", node.BytesIngress, name, app.Protocol, node.Colo, zone.Name)
+				ch <- collector.updateMetric("bytes_egress", node.BytesEgress, name, app.Protocol, node.Colo, zone.Name)
+				ch <- collector.updateMetric("connection_duration_milliseconds", node.DurationAvgMs, name, app.Protocol, node.Colo, zone.Name)
 			}
 		} else {
 			log.Println("Fetch failed :", err)
@@ -385,13 +1269,370 @@ func (collector *CloudflareCollector) collectWorkers(ch chan<- prometheus.Metric
 		return err
 	}
 	for _, node := range resp.Viewer.Accounts[0].Workers {
-		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP50), node.Info.Name, collector.account.Name, "50")
-		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP75), node.Info.Name, collector.account.Name, "75")
-		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP99), node.Info.Name, collector.account.Name, "99")
-		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP999), node.Info.Name, collector.account.Name, "99.9")
-		ch <- collector.updateMetric("errors", float64(node.Sum.Errors), node.Info.Name, collector.account.Name)
-		ch <- collector.updateMetric("requests", float64(node.Sum.Requests), node.Info.Name, collector.account.Name)
-		ch <- collector.updateMetric("subrequests", float64(node.Sum.SubRequests), node.Info.Name, collector.account.Name)
+		info := node.Info
+		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP50), info.Name, collector.account.Name, "50", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP75), info.Name, collector.account.Name, "75", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP99), info.Name, collector.account.Name, "99", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("cputime", float64(node.Quantiles.CpuTimeP999), info.Name, collector.account.Name, "99.9", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("walltime", float64(node.Quantiles.WallTimeP50), info.Name, collector.account.Name, "50", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("walltime", float64(node.Quantiles.WallTimeP75), info.Name, collector.account.Name, "75", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("walltime", float64(node.Quantiles.WallTimeP99), info.Name, collector.account.Name, "99", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("walltime", float64(node.Quantiles.WallTimeP999), info.Name, collector.account.Name, "99.9", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("duration", float64(node.Quantiles.DurationP50), info.Name, collector.account.Name, "50", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("duration", float64(node.Quantiles.DurationP75), info.Name, collector.account.Name, "75", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("duration", float64(node.Quantiles.DurationP99), info.Name, collector.account.Name, "99", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("duration", float64(node.Quantiles.DurationP999), info.Name, collector.account.Name, "99.9", info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("errors", float64(node.Sum.Errors), info.Name, collector.account.Name, info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("requests", float64(node.Sum.Requests), info.Name, collector.account.Name, info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+		ch <- collector.updateMetric("subrequests", float64(node.Sum.SubRequests), info.Name, collector.account.Name, info.Status, info.UsageModel, info.Environment, info.DispatchNamespace)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectCron(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Cron Trigger metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	resp, err := getCloudflareWorkerCronMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	type cronStatus struct {
+		cronTrigger
+		status string
+	}
+	invocations := make(map[cronStatus]int)
+	cpuTime := make(map[cronTrigger]float64)
+	for _, node := range resp.Viewer.Accounts[0].CronInvocations {
+		trigger := cronTrigger{script: node.ScriptName, cron: node.Cron}
+		invocations[cronStatus{trigger, node.Status}]++
+		if node.CpuTimeUs > cpuTime[trigger] {
+			cpuTime[trigger] = node.CpuTimeUs
+		}
+		collector.crons.update(trigger, node.Datetime, node.Status == "success")
+	}
+	collector.crons.expire(time.Now(), cronRetention)
+	err = collector.crons.save(collector.cronStateFile)
+	if err != nil {
+		log.Println("Unable to save the cron trigger table :", err)
+	}
+	for key, count := range invocations {
+		ch <- collector.updateMetric("cron_invocations", float64(count), key.script, collector.account.Name, key.cron, key.status)
+	}
+	for trigger, value := range cpuTime {
+		ch <- collector.updateMetric("cron_cputime", value, trigger.script, collector.account.Name, trigger.cron)
+	}
+	for trigger, state := range collector.crons {
+		ch <- collector.updateMetric("cron_seconds_since_last_success", state.sinceLastSuccess(time.Now()).Seconds(), trigger.script, collector.account.Name, trigger.cron)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectPages(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Pages Functions metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	resp, err := getCloudflarePagesFunctionsMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	for _, node := range resp.Viewer.Accounts[0].PagesFunctions {
+		ch <- collector.updateMetric("functions_cputime", float64(node.Quantiles.CpuTimeP50), node.Info.Name, collector.account.Name, "50", node.Info.Status)
+		ch <- collector.updateMetric("functions_cputime", float64(node.Quantiles.CpuTimeP75), node.Info.Name, collector.account.Name, "75", node.Info.Status)
+		ch <- collector.updateMetric("functions_cputime", float64(node.Quantiles.CpuTimeP99), node.Info.Name, collector.account.Name, "99", node.Info.Status)
+		ch <- collector.updateMetric("functions_cputime", float64(node.Quantiles.CpuTimeP999), node.Info.Name, collector.account.Name, "99.9", node.Info.Status)
+		ch <- collector.updateMetric("functions_errors", float64(node.Sum.Errors), node.Info.Name, collector.account.Name, node.Info.Status)
+		ch <- collector.updateMetric("functions_requests", float64(node.Sum.Requests), node.Info.Name, collector.account.Name, node.Info.Status)
+		ch <- collector.updateMetric("functions_subrequests", float64(node.Sum.SubRequests), node.Info.Name, collector.account.Name, node.Info.Status)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectTunnels(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Tunnel status for %s\n", collector.accountID)
+	tunnels, err := getCloudflareTunnels(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	for _, tunnel := range tunnels {
+		for _, status := range tunnelStatuses {
+			value := 0.0
+			if tunnel.Status == status {
+				value = 1
+			}
+			ch <- collector.updateMetric("status", value, tunnel.ID, tunnel.Name, status, collector.account.Name)
+		}
+		colos := make(map[string]int)
+		versions := make(map[string]int)
+		for _, conn := range tunnel.Connections {
+			if !conn.IsPendingReconnect {
+				colos[conn.ColoName]++
+			}
+			versions[conn.ClientVersion]++
+		}
+		for colo, count := range colos {
+			ch <- collector.updateMetric("active_connections", float64(count), tunnel.ID, tunnel.Name, colo, collector.account.Name)
+		}
+		for version, count := range versions {
+			ch <- collector.updateMetric("connector_versions", float64(count), tunnel.ID, tunnel.Name, version, collector.account.Name)
+		}
+		if last := tunnel.lastChange(); !last.IsZero() {
+			ch <- collector.updateMetric("seconds_since_last_change", time.Since(last).Seconds(), tunnel.ID, tunnel.Name, collector.account.Name)
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectGateway(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Gateway metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	policies, err := getCloudflareGatewayRules(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Gateway policies :", err)
+	}
+	categories, err := getCloudflareGatewayCategories(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Gateway categories :", err)
+	}
+	resp, err := getCloudflareGatewayMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	account := resp.Viewer.Accounts[0]
+	for _, node := range account.GatewayDNS {
+		ch <- collector.updateMetric("dns_queries", float64(node.Count), node.Dimensions.PolicyID, nameOrID(policies, node.Dimensions.PolicyID), resolverDecision(node.Dimensions.ResolverDecision), collector.account.Name)
+	}
+	for _, node := range account.GatewayDNSCategories {
+		category := nameOrID(categories, strconv.Itoa(node.Dimensions.CategoryID))
+		ch <- collector.updateMetric("dns_queries_by_category", float64(node.Count), category, resolverDecision(node.Dimensions.ResolverDecision), collector.account.Name)
+	}
+	for _, node := range account.GatewayHTTP {
+		ch <- collector.updateMetric("http_requests", float64(node.Count), node.Dimensions.PolicyID, nameOrID(policies, node.Dimensions.PolicyID), node.Dimensions.Action, collector.account.Name)
+	}
+	for _, node := range account.GatewayNetwork {
+		ch <- collector.updateMetric("network_sessions", float64(node.Count), node.Dimensions.PolicyID, nameOrID(policies, node.Dimensions.PolicyID), node.Dimensions.Action, collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectAccess(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Access metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	apps, err := getCloudflareAccessApplications(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Access applications :", err)
+	}
+	logins, err := getCloudflareAccessLogins(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err == nil {
+		counts := make(map[accessLoginKey]int)
+		for _, login := range logins {
+			counts[accessLoginKey{login.AppUID, login.Connection, login.outcome()}]++
+		}
+		for key, count := range counts {
+			ch <- collector.updateMetric("logins", float64(count), key.appID, nameOrID(apps, key.appID), key.identityProvider, key.outcome, collector.account.Name)
+		}
+	} else {
+		log.Println("Fetch Failed:", err)
+	}
+	sessions, counted, updated := collector.accessSessions.get()
+	if time.Since(updated) >= collector.accessSessionsRefresh {
+		collector.accessSessions.refresh(collector.accountID, collector.apiEmail, collector.apiKey)
+	}
+	if counted {
+		ch <- collector.updateMetric("active_sessions", float64(sessions), collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectRUM(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Web Analytics metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	sites, err := getCloudflareRUMSites(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Web Analytics sites :", err)
+	}
+	resp, err := getCloudflareRUMMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	pageloads := make(map[rumKey]int)
+	for _, node := range resp.Viewer.Accounts[0].RUMPageloads {
+		d := node.Dimensions
+		pageloads[rumKey{nameOrID(sites, d.SiteTag), pathPrefix(d.RequestPath), d.CountryName, d.DeviceType}] += node.Count
+	}
+	for key, count := range pageloads {
+		ch <- collector.updateMetric("pageloads", float64(count), key.site, key.pathPrefix, key.country, key.deviceType, collector.account.Name)
+	}
+
+	// Quantiles can not be added up, so the ones of every path are weighted by its number of events
+	vitals := make(map[rumKey]*rumVitalsStats)
+	for _, node := range resp.Viewer.Accounts[0].RUMWebVitals {
+		d := node.Dimensions
+		key := rumKey{nameOrID(sites, d.SiteTag), pathPrefix(d.RequestPath), d.CountryName, d.DeviceType}
+		if vitals[key] == nil {
+			vitals[key] = &rumVitalsStats{quantiles: make(map[string]float64)}
+		}
+		vitals[key].count += node.Count
+		for field, value := range node.Quantiles {
+			vitals[key].quantiles[field] += value * float64(node.Count)
+		}
+	}
+	for key, stats := range vitals {
+		if stats.count == 0 {
+			continue
+		}
+		for field, name := range rumVitals {
+			for _, percentile := range rumPercentiles {
+				value := stats.quantiles[field+"P"+percentile] / float64(stats.count)
+				ch <- collector.updateMetric(name, value, key.site, key.pathPrefix, key.country, key.deviceType, collector.account.Name, percentile)
+			}
+		}
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectStorage(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Storage metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	kvNames, err := getCloudflareKVNamespaces(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch KV namespaces :", err)
+	}
+	doNames, err := getCloudflareDurableObjectNamespaces(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Durable Objects namespaces :", err)
+	}
+	resp, err := getCloudflareStorageMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	account := resp.Viewer.Accounts[0]
+	for _, node := range account.KVOperations {
+		ch <- collector.updateMetric("kv_operations", float64(node.Sum.Requests), nameOrID(kvNames, node.Dimensions.NamespaceID), node.Dimensions.ActionType, collector.account.Name)
+	}
+	for _, node := range account.R2Operations {
+		ch <- collector.updateMetric("r2_operations", float64(node.Sum.Requests), node.Dimensions.BucketName, node.Dimensions.ActionType, collector.account.Name)
+	}
+	for _, node := range account.R2Storage {
+		ch <- collector.updateMetric("r2_stored_bytes", float64(node.Max.PayloadSize+node.Max.MetadataSize), node.Dimensions.BucketName, collector.account.Name)
+		ch <- collector.updateMetric("r2_objects", float64(node.Max.ObjectCount), node.Dimensions.BucketName, collector.account.Name)
+	}
+	for _, node := range account.DurableObjectsInvocations {
+		ch <- collector.updateMetric("durable_objects_requests", float64(node.Sum.Requests), nameOrID(doNames, node.Dimensions.NamespaceID), collector.account.Name)
+		ch <- collector.updateMetric("durable_objects_walltime", node.Sum.WallTime, nameOrID(doNames, node.Dimensions.NamespaceID), collector.account.Name)
+	}
+	for _, node := range account.DurableObjectsStorage {
+		ch <- collector.updateMetric("durable_objects_stored_bytes", float64(node.Max.StoredBytes), collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectD1(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting D1 metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	names, err := getCloudflareD1Databases(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch D1 databases :", err)
+	}
+	resp, err := getCloudflareD1Metrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	for _, node := range resp.Viewer.Accounts[0].D1Queries {
+		database := nameOrID(names, node.Dimensions.DatabaseID)
+		ch <- collector.updateMetric("read_queries", float64(node.Sum.ReadQueries), database, collector.account.Name)
+		ch <- collector.updateMetric("write_queries", float64(node.Sum.WriteQueries), database, collector.account.Name)
+		ch <- collector.updateMetric("rows_read", float64(node.Sum.RowsRead), database, collector.account.Name)
+		ch <- collector.updateMetric("rows_written", float64(node.Sum.RowsWritten), database, collector.account.Name)
+		ch <- collector.updateMetric("query_batch_milliseconds", node.Quantiles.QueryBatchTimeMsP50, database, collector.account.Name, "50")
+		ch <- collector.updateMetric("query_batch_milliseconds", node.Quantiles.QueryBatchTimeMsP90, database, collector.account.Name, "90")
+	}
+	for _, node := range resp.Viewer.Accounts[0].D1Storage {
+		ch <- collector.updateMetric("database_size_bytes", float64(node.Max.DatabaseSizeBytes), nameOrID(names, node.Dimensions.DatabaseID), collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectQueues(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Queues metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	names, err := getCloudflareQueues(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch queues :", err)
+	}
+	resp, err := getCloudflareQueuesMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	stats := make(map[string]*queueStats)
+	for _, node := range resp.Viewer.Accounts[0].QueueOperations {
+		queue := nameOrID(names, node.Dimensions.QueueID)
+		if stats[queue] == nil {
+			stats[queue] = &queueStats{}
+		}
+		switch {
+		case node.Dimensions.ActionType == "WriteMessage":
+			stats[queue].produced += node.Count
+		case node.Dimensions.Outcome == "retry":
+			stats[queue].retried += node.Count
+		case node.Dimensions.Outcome == "dlq":
+			stats[queue].deadLettered += node.Count
+		case node.Dimensions.ActionType == "DeleteMessage":
+			stats[queue].consumed += node.Count
+		}
+	}
+	for queue, stat := range stats {
+		ch <- collector.updateMetric("messages_produced", float64(stat.produced), queue, collector.account.Name)
+		ch <- collector.updateMetric("messages_consumed", float64(stat.consumed), queue, collector.account.Name)
+		ch <- collector.updateMetric("messages_retried", float64(stat.retried), queue, collector.account.Name)
+		ch <- collector.updateMetric("messages_dead_lettered", float64(stat.deadLettered), queue, collector.account.Name)
+	}
+	for _, node := range resp.Viewer.Accounts[0].QueueBacklog {
+		ch <- collector.updateMetric("backlog_messages", node.Avg.Messages, nameOrID(names, node.Dimensions.QueueID), collector.account.Name)
+		ch <- collector.updateMetric("backlog_bytes", node.Avg.Bytes, nameOrID(names, node.Dimensions.QueueID), collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectMedia(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Images and Stream metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	images, err := getCloudflareImagesStats(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Images stats :", err)
+	} else {
+		ch <- collector.updateMetric("images_stored", float64(images.Count.Current), collector.account.Name)
+		ch <- collector.updateMetric("images_allowed", float64(images.Count.Allowed), collector.account.Name)
+	}
+	stream, err := getCloudflareStreamStorageUsage(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch Stream storage usage :", err)
+	} else {
+		ch <- collector.updateMetric("stream_minutes_stored", stream.TotalStorageMinutes, collector.account.Name)
+		ch <- collector.updateMetric("stream_minutes_limit", stream.TotalStorageMinutesLimit, collector.account.Name)
+		ch <- collector.updateMetric("stream_videos", float64(stream.VideoCount), collector.account.Name)
+	}
+	resp, err := getCloudflareMediaMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch Failed:", err)
+		return err
+	}
+	account := resp.Viewer.Accounts[0]
+	for _, node := range account.ImagesTransformations {
+		ch <- collector.updateMetric("images_transformations", float64(node.Sum.Transformations), collector.account.Name)
+	}
+	for _, node := range account.ImagesDelivered {
+		ch <- collector.updateMetric("images_delivered", float64(node.Sum.Requests), collector.account.Name)
+	}
+	for _, node := range account.StreamMinutesViewed {
+		ch <- collector.updateMetric("stream_minutes_delivered", node.Sum.MinutesViewed, collector.account.Name)
 	}
 	return nil
 }
@@ -427,9 +1668,133 @@ func (collector *CloudflareCollector) collectNetwork(ch chan<- prometheus.Metric
 	} else {
 		log.Println("Fetch failed :", err)
 	}
+
+	// Attacks are only active while the timeline shows them, a failed fetch must not leave them active
+	for _, a := range collector.attacks {
+		a.Active = false
+	}
+	resp, err = getCloudflareAttackTimeline(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err == nil && len(resp.Viewer.Accounts) == 0 {
+		err = errors.New("no attack timeline returned for " + collector.accountID)
+	}
+	if err == nil {
+		for _, node := range resp.Viewer.Accounts[0].AttackTimeline {
+			collector.attacks.update(node.NetworkDimensions.AttackID, node.NetworkDimensions.DatetimeMinute, node.Sum.Bits, node.Sum.Packets)
+		}
+		collector.attacks.expire(time.Now(), collector.attackQuietPeriod)
+		err = collector.attacks.save(collector.attackStateFile)
+		if err != nil {
+			log.Println("Unable to save the attack table :", err)
+		}
+	} else {
+		log.Println("Fetch failed :", err)
+	}
+	for attackID, a := range collector.attacks {
+		active := 0.0
+		if a.Active {
+			active = 1
+		}
+		ch <- collector.updateMetric("attack_active", active, attackID, collector.account.Name)
+		ch <- collector.updateMetric("attack_start_timestamp_seconds", float64(a.Start.Unix()), attackID, collector.account.Name)
+		ch <- collector.updateMetric("attack_duration_seconds", a.LastSeen.Sub(a.Start).Seconds(), attackID, collector.account.Name)
+		ch <- collector.updateMetric("attack_peak_bps", a.PeakBPS, attackID, collector.account.Name)
+		ch <- collector.updateMetric("attack_peak_pps", a.PeakPPS, attackID, collector.account.Name)
+	}
+	return nil
+}
+
+func (collector *CloudflareCollector) collectMagicTransit(ch chan<- prometheus.Metric) error {
+
+	log.Printf("Getting Magic Transit metrics for %s from %s to %s \n", collector.accountID, collector.startDate, collector.endDate)
+	tunnels, err := getCloudflareMagicTunnels(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch tunnels :", err)
+	}
+	prefixes, err := getCloudflareIPPrefixes(collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Unable to fetch prefixes :", err)
+	}
+	resp, err := getCloudflareMagicTransitMetrics(collector.startDate, collector.endDate, collector.accountID, collector.apiEmail, collector.apiKey)
+	if err != nil {
+		log.Println("Fetch failed :", err)
+		return err
+	}
+	account := resp.Viewer.Accounts[0]
+	for _, node := range account.MagicFirewall {
+		ch <- collector.updateMetric("firewall_bits", float64(node.Sum.Bits), node.Dimensions.RuleID, node.Dimensions.Outcome, collector.account.Name)
+		ch <- collector.updateMetric("firewall_packets", float64(node.Sum.Packets), node.Dimensions.RuleID, node.Dimensions.Outcome, collector.account.Name)
+	}
+	for _, node := range account.MagicTunnels {
+		tunnelType := tunnels[node.Dimensions.TunnelName]
+		ch <- collector.updateMetric("tunnel_bits", float64(node.Sum.Bits), node.Dimensions.TunnelName, tunnelType, node.Dimensions.Direction, collector.account.Name)
+		ch <- collector.updateMetric("tunnel_packets", float64(node.Sum.Packets), node.Dimensions.TunnelName, tunnelType, node.Dimensions.Direction, collector.account.Name)
+	}
+	for _, node := range account.MagicHealthChecks {
+		ch <- collector.updateMetric("tunnel_health_checks", float64(node.Count), node.Dimensions.TunnelName, tunnels[node.Dimensions.TunnelName], node.Dimensions.ResultStatus, collector.account.Name)
+	}
+
+	// Several subnets can belong to the same prefix, so they are added up before being exported
+	type prefixOutcome struct {
+		prefix  IPPrefix
+		outcome string
+	}
+	prefixTraffic := make(map[prefixOutcome]SumAttacks)
+	for _, node := range account.MagicPrefixes {
+		key := prefixOutcome{prefixForSubnet(prefixes, node.Dimensions.IPDestinationSubnet), node.Dimensions.Outcome}
+		traffic := prefixTraffic[key]
+		traffic.Bits += node.Sum.Bits
+		traffic.Packets += node.Sum.Packets
+		prefixTraffic[key] = traffic
+	}
+	for key, traffic := range prefixTraffic {
+		ch <- collector.updateMetric("prefix_bits", float64(traffic.Bits), key.prefix.CIDR, key.prefix.Description, key.outcome, collector.account.Name)
+		ch <- collector.updateMetric("prefix_packets", float64(traffic.Packets), key.prefix.CIDR, key.prefix.Description, key.outcome, collector.account.Name)
+	}
 	return nil
 }
 
+// parseQuantiles converts a comma separated list of quantiles into a slice, rejecting the ones GraphQL does not provide
+func parseQuantiles(quantiles string) ([]float64, error) {
+	var values []float64
+	for _, q := range strings.Split(quantiles, ",") {
+		q = strings.TrimSpace(q)
+		if q == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(q, 64)
+		if err != nil {
+			return nil, err
+		}
+		if !containsFloat(supportedQuantiles, value) {
+			return nil, errors.New("Unsupported quantile " + q + ", valid values are: 0.5, 0.75, 0.9, 0.95, 0.99, 0.999")
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// quantileSuffix returns the suffix GraphQL uses for a given quantile, 0.999 becomes P999
+func quantileSuffix(quantile float64) string {
+	return "P" + strings.Replace(strconv.FormatFloat(quantile*100, 'f', -1, 64), ".", "", 1)
+}
+
+func containsFloat(elements []float64, element float64) bool {
+	for _, e := range elements {
+		if element == e {
+			return true
+		}
+	}
+	return false
+}
+
+// nameOrID returns the name an ID resolves to, falling back to the ID when it is unknown
+func nameOrID(names map[string]string, id string) string {
+	if name, ok := names[id]; ok && name != "" {
+		return name
+	}
+	return id
+}
+
 func contains(elements []string, element string) bool {
 	for _, e := range elements {
 		if element == e {
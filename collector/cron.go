@@ -0,0 +1,79 @@
+package collector
+
+import "time"
+
+// cronRetention is how long a cron trigger is kept after its last invocation
+const cronRetention = 7 * 24 * time.Hour
+
+// cronTrigger identifies a cron schedule of a worker
+type cronTrigger struct {
+	script string
+	cron   string
+}
+
+// cronState keeps track of the invocations of a cron trigger across scrapes
+type cronState struct {
+	Script      string    `json:"script"`
+	Cron        string    `json:"cron"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+	LastSuccess time.Time `json:"lastSuccess"`
+}
+
+// sinceLastSuccess returns the time elapsed since the last successful invocation,
+// or since the trigger was first seen when it has never succeeded
+func (state *cronState) sinceLastSuccess(now time.Time) time.Duration {
+	if state.LastSuccess.IsZero() {
+		return now.Sub(state.FirstSeen)
+	}
+	return now.Sub(state.LastSuccess)
+}
+
+// cronTable stores the known cron triggers
+type cronTable map[cronTrigger]*cronState
+
+// update records an invocation of the trigger
+func (table cronTable) update(trigger cronTrigger, when time.Time, success bool) {
+	state, ok := table[trigger]
+	if !ok {
+		state = &cronState{Script: trigger.script, Cron: trigger.cron, FirstSeen: when}
+		table[trigger] = state
+	}
+	if when.Before(state.FirstSeen) {
+		state.FirstSeen = when
+	}
+	if when.After(state.LastSeen) {
+		state.LastSeen = when
+	}
+	if success && when.After(state.LastSuccess) {
+		state.LastSuccess = when
+	}
+}
+
+// expire removes the triggers that have not been invoked during the retention period
+func (table cronTable) expire(now time.Time, retention time.Duration) {
+	for trigger, state := range table {
+		if now.Sub(state.LastSeen) > retention {
+			delete(table, trigger)
+		}
+	}
+}
+
+func loadCronTable(path string) (cronTable, error) {
+	var states []*cronState
+	table := make(cronTable)
+	err := loadStateFile(path, &states)
+	for _, state := range states {
+		table[cronTrigger{script: state.Script, cron: state.Cron}] = state
+	}
+	return table, err
+}
+
+// save stores the table as a list, as JSON objects can't be keyed by cronTrigger
+func (table cronTable) save(path string) error {
+	states := []*cronState{}
+	for _, state := range table {
+		states = append(states, state)
+	}
+	return saveStateFile(path, states)
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCronTable(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	failing := cronTrigger{script: "backup", cron: "*/5 * * * *"}
+	working := cronTrigger{script: "cleanup", cron: "0 * * * *"}
+
+	table := make(cronTable)
+	table.update(failing, start, false)
+	table.update(failing, start.Add(5*time.Minute), false)
+	table.update(working, start, true)
+	table.update(working, start.Add(time.Hour), false)
+
+	now := start.Add(2 * time.Hour)
+	if since := table[failing].sinceLastSuccess(now); since != 2*time.Hour {
+		t.Errorf("Never succeeded trigger reported %v", since)
+	}
+	if since := table[working].sinceLastSuccess(now); since != 2*time.Hour {
+		t.Errorf("Working trigger reported %v", since)
+	}
+
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cron.json")
+	if err = table.save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadCronTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 || !loaded[working].LastSuccess.Equal(start) {
+		t.Errorf("Unexpected table after reload %v", loaded)
+	}
+
+	loaded.expire(start.Add(cronRetention+time.Hour/2), cronRetention)
+	if _, ok := loaded[failing]; ok || len(loaded) != 1 {
+		t.Errorf("Trigger was not expired %v", loaded)
+	}
+}
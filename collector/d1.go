@@ -0,0 +1,65 @@
+package collector
+
+import "encoding/json"
+
+type D1Database struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+func getCloudflareD1Metrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				d1Queries: d1AnalyticsAdaptiveGroups(
+					limit: 10000
+					filter: {datetimeMinute_geq: $startDate, datetimeMinute_leq: $endDate}
+				) {
+					sum {
+						readQueries
+						writeQueries
+						rowsRead
+						rowsWritten
+					}
+					quantiles {
+						queryBatchTimeMsP50
+						queryBatchTimeMsP90
+					}
+					dimensions {
+						databaseId
+					}
+				}
+				d1Storage: d1StorageAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					max {
+						databaseSizeBytes
+					}
+					dimensions {
+						databaseId
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareD1Databases maps the ID of every D1 database of the account to its name
+func getCloudflareD1Databases(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/d1/database?per_page=100", mail, key, func(result json.RawMessage) error {
+		var databases []D1Database
+		err := json.Unmarshal(result, &databases)
+		for _, database := range databases {
+			names[database.UUID] = database.Name
+		}
+		return err
+	})
+	return names, err
+}
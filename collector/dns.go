@@ -3,7 +3,6 @@ package collector
 import (
 	"encoding/json"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -32,22 +31,29 @@ type DNSAnalytics struct {
 	} `json:"totals"`
 }
 
-func doRequest(url, mail, key string) (respData []byte, err error) {
-	client := http.Client{Timeout: time.Second * 5}
+// httpClient is shared by every REST request so connections are reused across scrapes
+var httpClient = &http.Client{Timeout: time.Second * 5}
 
+// doRequest returns the body of the response, along with an error when the status is not 2xx
+// so callers can still decode the API errors it carries
+func doRequest(url, mail, key string) (respData []byte, err error) {
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("X-Auth-Key", key)
 	request.Header.Set("X-Auth-Email", mail)
-	response, err := client.Do(request)
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return body, errors.New(url + " returned " + response.Status)
 	}
 	return body, nil
 }
@@ -76,9 +82,42 @@ func getCloudflareDNSReport(uri, mail, key string) (respData DNSAnalytics, err e
 	return response.Result, nil
 }
 
-func getCloudflareDNSMetrics(zoneID, mail, key, options string) (respData DNSAnalytics, err error) {
-	uri := "https://api.cloudflare.com/client/v4/zones/" + zoneID + "/dns_analytics/report?" + options
-	return getCloudflareDNSReport(uri, mail, key)
+func getCloudflareDNSMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			zones(filter: { zoneTag: $zoneTag }) {
+				dnsQueries: dnsAnalyticsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					avg {
+						responseTime
+					}
+					quantiles {
+						responseTimeP50
+						responseTimeP90
+						responseTimeP99
+					}
+					dimensions {
+						queryName
+						queryType
+						responseCode
+						responseCached
+						coloName
+						ipVersion
+						protocol
+						queryEdns
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, zoneID, "")
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
 }
 
 func getCloudflareDNSFirewallMetrics(accountID, vdnsID, mail, key, options string) (respData DNSAnalytics, err error) {
@@ -0,0 +1,122 @@
+package collector
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"gopkg.in/yaml.v2"
+)
+
+// DesiredRecord is a DNS record as declared on the desired state file
+type DesiredRecord struct {
+	Zone    string `yaml:"zone"`
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Content string `yaml:"content"`
+	Proxied bool   `yaml:"proxied"`
+	TTL     int    `yaml:"ttl"`
+}
+
+// DesiredState lists the DNS records every zone is expected to have
+type DesiredState struct {
+	Records []DesiredRecord `yaml:"records"`
+}
+
+// RecordDrift describes a record that does not match the desired state
+type RecordDrift struct {
+	Name    string
+	Type    string
+	Content string
+	Drift   string
+}
+
+type recordKey struct {
+	name  string
+	rtype string
+}
+
+func loadDesiredState(path string) (DesiredState, error) {
+	var state DesiredState
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	err = yaml.Unmarshal(content, &state)
+	return state, err
+}
+
+// zones returns the names of the zones declared on the desired state
+func (state DesiredState) zones() []string {
+	zones := []string{}
+	for _, record := range state.Records {
+		if !contains(zones, record.Zone) {
+			zones = append(zones, record.Zone)
+		}
+	}
+	return zones
+}
+
+// recordDrift compares the records of a zone with its desired state. Records are matched by name and
+// type, a record whose content, proxy status or TTL differs is reported as changed. When several records
+// share a name and type, the ones with the same content are paired first.
+// A TTL of 0 on the desired state means any TTL is accepted.
+func recordDrift(state DesiredState, zoneName string, records []cloudflare.DNSRecord) []RecordDrift {
+	desired := make(map[recordKey][]DesiredRecord)
+	for _, record := range state.Records {
+		if record.Zone == zoneName {
+			key := recordKey{strings.ToLower(record.Name), strings.ToUpper(record.Type)}
+			desired[key] = append(desired[key], record)
+		}
+	}
+	live := make(map[recordKey][]cloudflare.DNSRecord)
+	for _, record := range records {
+		key := recordKey{strings.ToLower(record.Name), strings.ToUpper(record.Type)}
+		live[key] = append(live[key], record)
+	}
+	drifts := []RecordDrift{}
+	for key, records := range live {
+		wanted := append([]DesiredRecord{}, desired[key]...)
+		unmatched := []cloudflare.DNSRecord{}
+		for _, record := range records {
+			i := desiredContent(wanted, record.Content)
+			if i < 0 {
+				unmatched = append(unmatched, record)
+				continue
+			}
+			if wanted[i].Proxied != record.Proxied || (wanted[i].TTL != 0 && wanted[i].TTL != record.TTL) {
+				drifts = append(drifts, RecordDrift{record.Name, record.Type, record.Content, "changed"})
+			}
+			wanted = append(wanted[:i], wanted[i+1:]...)
+		}
+		// The records left on both sides had their content edited
+		for i, record := range unmatched {
+			if i < len(wanted) {
+				drifts = append(drifts, RecordDrift{record.Name, record.Type, record.Content, "changed"})
+			} else {
+				drifts = append(drifts, RecordDrift{record.Name, record.Type, record.Content, "extra"})
+			}
+		}
+		if len(unmatched) < len(wanted) {
+			desired[key] = wanted[len(unmatched):]
+		} else {
+			delete(desired, key)
+		}
+	}
+	for _, records := range desired {
+		for _, record := range records {
+			drifts = append(drifts, RecordDrift{record.Name, record.Type, record.Content, "missing"})
+		}
+	}
+	return drifts
+}
+
+// desiredContent returns the index of the desired record with the given content, or -1
+func desiredContent(records []DesiredRecord, content string) int {
+	for i, record := range records {
+		if record.Content == content {
+			return i
+		}
+	}
+	return -1
+}
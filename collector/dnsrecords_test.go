@@ -0,0 +1,47 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestRecordDrift(t *testing.T) {
+	state := DesiredState{Records: []DesiredRecord{
+		{Zone: "example.com", Name: "www.example.com", Type: "A", Content: "192.0.2.1", Proxied: true},
+		{Zone: "example.com", Name: "api.example.com", Type: "A", Content: "192.0.2.2", Proxied: true, TTL: 1},
+		{Zone: "example.com", Name: "mail.example.com", Type: "MX", Content: "mx.example.com", TTL: 300},
+		{Zone: "example.com", Name: "ftp.example.com", Type: "A", Content: "192.0.2.4"},
+		{Zone: "example.com", Name: "lb.example.com", Type: "A", Content: "192.0.2.10"},
+		{Zone: "example.com", Name: "lb.example.com", Type: "A", Content: "192.0.2.11"},
+		{Zone: "other.com", Name: "other.com", Type: "A", Content: "192.0.2.3"},
+	}}
+	records := []cloudflare.DNSRecord{
+		{Name: "www.example.com", Type: "A", Content: "192.0.2.1", Proxied: true, TTL: 1},
+		{Name: "api.example.com", Type: "A", Content: "192.0.2.2", Proxied: false, TTL: 1},
+		{Name: "dev.example.com", Type: "CNAME", Content: "www.example.com", Proxied: true, TTL: 1},
+		{Name: "ftp.example.com", Type: "A", Content: "192.0.2.5", TTL: 1},
+		{Name: "lb.example.com", Type: "A", Content: "192.0.2.10", TTL: 1},
+		{Name: "lb.example.com", Type: "A", Content: "192.0.2.12", TTL: 1},
+	}
+
+	drifts := make(map[string]string)
+	for _, drift := range recordDrift(state, "example.com", records) {
+		drifts[drift.Name] = drift.Drift
+	}
+	expected := map[string]string{
+		"api.example.com":  "changed",
+		"dev.example.com":  "extra",
+		"ftp.example.com":  "changed",
+		"lb.example.com":   "changed",
+		"mail.example.com": "missing",
+	}
+	if len(drifts) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, drifts)
+	}
+	for name, drift := range expected {
+		if drifts[name] != drift {
+			t.Errorf("Expected %s to be %s, got %s", name, drift, drifts[name])
+		}
+	}
+}
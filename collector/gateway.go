@@ -0,0 +1,110 @@
+package collector
+
+import "strconv"
+
+// gatewayResolverDecisions names the resolverDecision codes of the Gateway DNS analytics
+var gatewayResolverDecisions = map[string]string{
+	"0":  "unknown",
+	"1":  "allowed_by_query_name",
+	"2":  "blocked_by_query_name",
+	"3":  "blocked_by_category",
+	"4":  "allowed_on_no_location",
+	"5":  "allowed_on_no_policy_match",
+	"6":  "blocked_always_category",
+	"7":  "override_for_safe_search",
+	"8":  "override_applied",
+	"9":  "blocked_rule",
+	"10": "allowed_rule",
+}
+
+// resolverDecision returns the name of a resolverDecision code, or the code itself when it is unknown
+func resolverDecision(code int) string {
+	return nameOrID(gatewayResolverDecisions, strconv.Itoa(code))
+}
+
+type GatewayRule struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+type GatewayCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func getCloudflareGatewayMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				gatewayDNS: gatewayResolverQueriesAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						policyId
+						resolverDecision
+					}
+				}
+				gatewayDNSCategories: gatewayResolverByCategoryAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						categoryId
+						resolverDecision
+					}
+				}
+				gatewayHTTP: gatewayL7RequestsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						policyId
+						action
+					}
+				}
+				gatewayNetwork: gatewayL4SessionsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						policyId
+						action
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareGatewayRules maps the ID of every Gateway policy of the account to its name
+func getCloudflareGatewayRules(accountID, mail, key string) (map[string]string, error) {
+	var rules []GatewayRule
+	err := getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/gateway/rules", mail, key, &rules)
+	names := make(map[string]string)
+	for _, rule := range rules {
+		names[rule.ID] = rule.Name
+	}
+	return names, err
+}
+
+// getCloudflareGatewayCategories maps the ID of every Gateway content and security category to its name
+func getCloudflareGatewayCategories(accountID, mail, key string) (map[string]string, error) {
+	var categories []GatewayCategory
+	err := getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/gateway/categories", mail, key, &categories)
+	names := make(map[string]string)
+	for _, category := range categories {
+		names[strconv.Itoa(category.ID)] = category.Name
+	}
+	return names, err
+}
@@ -0,0 +1,43 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const gatewayPayload = `{
+	"viewer": {
+		"accounts": [{
+			"gatewayDNS": [
+				{"count": 120, "dimensions": {"policyId": "", "resolverDecision": 5}},
+				{"count": 8, "dimensions": {"policyId": "f1a9c0b2d3e4", "resolverDecision": 9}}
+			],
+			"gatewayDNSCategories": [{"count": 3, "dimensions": {"categoryId": 68, "resolverDecision": 3}}],
+			"gatewayHTTP": [{"count": 42, "dimensions": {"policyId": "a7b8c9d0e1f2", "action": "block"}}],
+			"gatewayNetwork": [{"count": 6, "dimensions": {"policyId": "a7b8c9d0e1f2", "action": "allow"}}]
+		}]
+	}
+}`
+
+func TestGatewayResponse(t *testing.T) {
+	var resp RespDataStruct
+	if err := json.Unmarshal([]byte(gatewayPayload), &resp); err != nil {
+		t.Fatalf("Error decoding the response: %v", err)
+	}
+	account := resp.Viewer.Accounts[0]
+	if decision := resolverDecision(account.GatewayDNS[1].Dimensions.ResolverDecision); decision != "blocked_rule" {
+		t.Errorf("Unexpected decision %s", decision)
+	}
+	if decision := resolverDecision(42); decision != "42" {
+		t.Errorf("Unknown decisions should fall back to their code, got %s", decision)
+	}
+	if node := account.GatewayDNSCategories[0]; node.Dimensions.CategoryID != 68 || resolverDecision(node.Dimensions.ResolverDecision) != "blocked_by_category" {
+		t.Errorf("Unexpected category node %+v", node)
+	}
+	if node := account.GatewayHTTP[0]; node.Count != 42 || node.Dimensions.Action != "block" || node.Dimensions.PolicyID != "a7b8c9d0e1f2" {
+		t.Errorf("Unexpected HTTP node %+v", node)
+	}
+	if node := account.GatewayNetwork[0]; node.Count != 6 || node.Dimensions.Action != "allow" {
+		t.Errorf("Unexpected network node %+v", node)
+	}
+}
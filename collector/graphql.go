@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	"time"
 
 	"github.com/machinebox/graphql"
 )
@@ -16,14 +17,64 @@ type Viewer struct {
 }
 
 type Account struct {
-	NetAttacks []AttackHistory `json:"attackHistory"`
-	Workers    []Worker        `json:"workers"`
+	NetAttacks     []AttackHistory `json:"attackHistory"`
+	AttackTimeline []AttackHistory `json:"attackTimeline"`
+	Workers        []Worker        `json:"workers"`
+
+	MagicFirewall     []MagicGroup `json:"magicFirewall"`
+	MagicTunnels      []MagicGroup `json:"magicTunnels"`
+	MagicHealthChecks []MagicGroup `json:"magicHealthChecks"`
+	MagicPrefixes     []MagicGroup `json:"magicPrefixes"`
+
+	CronInvocations []CronInvocation `json:"cronInvocations"`
+	PagesFunctions  []Worker         `json:"pagesFunctions"`
+
+	KVOperations              []StorageGroup `json:"kvOperations"`
+	R2Operations              []StorageGroup `json:"r2Operations"`
+	R2Storage                 []StorageGroup `json:"r2Storage"`
+	DurableObjectsInvocations []StorageGroup `json:"durableObjectsInvocations"`
+	DurableObjectsStorage     []StorageGroup `json:"durableObjectsStorage"`
+
+	D1Queries []D1Group `json:"d1Queries"`
+	D1Storage []D1Group `json:"d1Storage"`
+
+	QueueOperations []QueueGroup `json:"queueOperations"`
+	QueueBacklog    []QueueGroup `json:"queueBacklog"`
+
+	GatewayDNS           []GatewayGroup `json:"gatewayDNS"`
+	GatewayDNSCategories []GatewayGroup `json:"gatewayDNSCategories"`
+	GatewayHTTP          []GatewayGroup `json:"gatewayHTTP"`
+	GatewayNetwork       []GatewayGroup `json:"gatewayNetwork"`
+
+	RUMPageloads []RUMGroup `json:"rumPageloads"`
+	RUMWebVitals []RUMGroup `json:"rumWebVitals"`
+
+	ImagesTransformations []MediaGroup `json:"imagesTransformations"`
+	ImagesDelivered       []MediaGroup `json:"imagesDelivered"`
+	StreamMinutesViewed   []MediaGroup `json:"streamMinutesViewed"`
 }
 
 type Zones struct {
 	Caching  []Caching  `json:"caching"`
 	Requests []Requests `json:"requests"`
 	FwEvents []FwEvent  `json:"fwEvents"`
+	Latency  []Latency  `json:"latency"`
+
+	BotScores     []BotGroup `json:"botScores"`
+	BotCategories []BotGroup `json:"botCategories"`
+	BotJA3        []BotGroup `json:"botJA3"`
+	BotJA4        []BotGroup `json:"botJA4"`
+	BotSources    []BotGroup `json:"botSources"`
+	BotActions    []BotGroup `json:"botActions"`
+
+	RateLimitEvents []FwEvent `json:"rateLimitEvents"`
+
+	DNSQueries []DNSGroup `json:"dnsQueries"`
+
+	CacheTiers           []CacheGroup `json:"cacheTiers"`
+	CacheHosts           []CacheGroup `json:"cacheHosts"`
+	CacheReserveRequests []CacheGroup `json:"cacheReserveRequests"`
+	CacheReserveStorage  []CacheGroup `json:"cacheReserveStorage"`
 }
 
 type Worker struct {
@@ -32,15 +83,35 @@ type Worker struct {
 	Sum       WorkersSum       `json:"sum"`
 }
 
+type CronInvocation struct {
+	ScriptName string    `json:"scriptName"`
+	Cron       string    `json:"cron"`
+	Status     string    `json:"status"`
+	Datetime   time.Time `json:"datetime"`
+	CpuTimeUs  float64   `json:"cpuTimeUs"`
+}
+
 type WorkersInfo struct {
-	Name string `json:"scriptName"`
+	Name              string `json:"scriptName"`
+	Status            string `json:"status"`
+	UsageModel        string `json:"usageModel"`
+	Environment       string `json:"environmentName"`
+	DispatchNamespace string `json:"dispatchNamespaceName"`
 }
 
 type WorkersQuantiles struct {
-	CpuTimeP50  float64 `json:"cpuTimeP50"`
-	CpuTimeP75  float64 `json:"cpuTimeP75"`
-	CpuTimeP99  float64 `json:"cpuTimeP99"`
-	CpuTimeP999 float64 `json:"cpuTimeP999"`
+	CpuTimeP50   float64 `json:"cpuTimeP50"`
+	CpuTimeP75   float64 `json:"cpuTimeP75"`
+	CpuTimeP99   float64 `json:"cpuTimeP99"`
+	CpuTimeP999  float64 `json:"cpuTimeP999"`
+	WallTimeP50  float64 `json:"wallTimeP50"`
+	WallTimeP75  float64 `json:"wallTimeP75"`
+	WallTimeP99  float64 `json:"wallTimeP99"`
+	WallTimeP999 float64 `json:"wallTimeP999"`
+	DurationP50  float64 `json:"durationP50"`
+	DurationP75  float64 `json:"durationP75"`
+	DurationP99  float64 `json:"durationP99"`
+	DurationP999 float64 `json:"durationP999"`
 }
 
 type WorkersSum struct {
@@ -49,18 +120,122 @@ type WorkersSum struct {
 	SubRequests int `json:"subrequests"`
 }
 
+type StorageGroup struct {
+	Sum        StorageSum        `json:"sum"`
+	Max        StorageMax        `json:"max"`
+	Dimensions StorageDimensions `json:"dimensions"`
+}
+
+type StorageSum struct {
+	Requests int     `json:"requests"`
+	WallTime float64 `json:"wallTime"`
+}
+
+type StorageMax struct {
+	ObjectCount  int `json:"objectCount"`
+	PayloadSize  int `json:"payloadSize"`
+	MetadataSize int `json:"metadataSize"`
+	StoredBytes  int `json:"storedBytes"`
+}
+
+type StorageDimensions struct {
+	NamespaceID string `json:"namespaceId"`
+	BucketName  string `json:"bucketName"`
+	ActionType  string `json:"actionType"`
+}
+
+type D1Group struct {
+	Sum        D1Sum        `json:"sum"`
+	Quantiles  D1Quantiles  `json:"quantiles"`
+	Max        D1Max        `json:"max"`
+	Dimensions D1Dimensions `json:"dimensions"`
+}
+
+type D1Sum struct {
+	ReadQueries  int `json:"readQueries"`
+	WriteQueries int `json:"writeQueries"`
+	RowsRead     int `json:"rowsRead"`
+	RowsWritten  int `json:"rowsWritten"`
+}
+
+type D1Quantiles struct {
+	QueryBatchTimeMsP50 float64 `json:"queryBatchTimeMsP50"`
+	QueryBatchTimeMsP90 float64 `json:"queryBatchTimeMsP90"`
+}
+
+type D1Max struct {
+	DatabaseSizeBytes int `json:"databaseSizeBytes"`
+}
+
+type D1Dimensions struct {
+	DatabaseID string `json:"databaseId"`
+}
+
+type QueueGroup struct {
+	Count      int             `json:"count"`
+	Avg        QueueAvg        `json:"avg"`
+	Dimensions QueueDimensions `json:"dimensions"`
+}
+
+type QueueAvg struct {
+	Messages float64 `json:"messages"`
+	Bytes    float64 `json:"bytes"`
+}
+
+type QueueDimensions struct {
+	QueueID    string `json:"queueId"`
+	ActionType string `json:"actionType"`
+	Outcome    string `json:"outcome"`
+}
+
+type GatewayGroup struct {
+	Count      int               `json:"count"`
+	Dimensions GatewayDimensions `json:"dimensions"`
+}
+
+type GatewayDimensions struct {
+	PolicyID         string `json:"policyId"`
+	CategoryID       int    `json:"categoryId"`
+	ResolverDecision int    `json:"resolverDecision"`
+	Action           string `json:"action"`
+}
+
+type RUMGroup struct {
+	Count      int                `json:"count"`
+	Quantiles  map[string]float64 `json:"quantiles"`
+	Dimensions RUMDimensions      `json:"dimensions"`
+}
+
+type RUMDimensions struct {
+	SiteTag     string `json:"siteTag"`
+	RequestPath string `json:"requestPath"`
+	CountryName string `json:"countryName"`
+	DeviceType  string `json:"deviceType"`
+}
+
+type MediaGroup struct {
+	Sum MediaSum `json:"sum"`
+}
+
+type MediaSum struct {
+	Transformations int     `json:"transformations"`
+	Requests        int     `json:"requests"`
+	MinutesViewed   float64 `json:"minutesViewed"`
+}
+
 type AttackHistory struct {
 	NetworkDimensions NetworkDimensions `json:"networkDimensions"`
 	Sum               SumAttacks        `json:"sum"`
 }
 
 type NetworkDimensions struct {
-	AttackID             string `json:"attackId"`
-	AttackMitigationType string `json:"attackMitigationType"`
-	AttackProtocol       string `json:"attackProtocol"`
-	AttackType           string `json:"attackType"`
-	ColoCountry          string `json:"coloCountry"`
-	DestinationPort      int    `json:"destinationPort"`
+	AttackID             string    `json:"attackId"`
+	AttackMitigationType string    `json:"attackMitigationType"`
+	AttackProtocol       string    `json:"attackProtocol"`
+	AttackType           string    `json:"attackType"`
+	ColoCountry          string    `json:"coloCountry"`
+	DestinationPort      int       `json:"destinationPort"`
+	DatetimeMinute       time.Time `json:"datetimeMinute"`
 }
 
 type SumAttacks struct {
@@ -68,6 +243,21 @@ type SumAttacks struct {
 	Packets int `json:"packets"`
 }
 
+type MagicGroup struct {
+	Count      int             `json:"count"`
+	Sum        SumAttacks      `json:"sum"`
+	Dimensions MagicDimensions `json:"dimensions"`
+}
+
+type MagicDimensions struct {
+	RuleID              string `json:"ruleId"`
+	Outcome             string `json:"outcome"`
+	TunnelName          string `json:"tunnelName"`
+	Direction           string `json:"direction"`
+	ResultStatus        string `json:"resultStatus"`
+	IPDestinationSubnet string `json:"ipDestinationSubnet"`
+}
+
 type Requests struct {
 	RequestsData RequestsData `json:"requestsData"`
 }
@@ -88,6 +278,7 @@ type RequestsData struct {
 }
 
 type Caching struct {
+	Count                int                  `json:"count"`
 	Dimensions           Dimensions           `json:"dimensions"`
 	SumEdgeResponseBytes SumEdgeResponseBytes `json:"sumEdgeResponseBytes"`
 }
@@ -131,6 +322,77 @@ type CountryMap struct {
 	Threats     int    `json:"threats"`
 }
 
+type Latency struct {
+	Quantiles map[string]float64 `json:"quantiles"`
+}
+
+type BotGroup struct {
+	Count      int           `json:"count"`
+	Dimensions BotDimensions `json:"dimensions"`
+}
+
+type BotDimensions struct {
+	BotScore            int    `json:"botScore"`
+	BotScoreSrcName     string `json:"botScoreSrcName"`
+	VerifiedBotCategory string `json:"verifiedBotCategory"`
+	JA3Hash             string `json:"ja3Hash"`
+	JA4                 string `json:"ja4"`
+	Source              string `json:"source"`
+	Action              string `json:"action"`
+}
+
+type DNSGroup struct {
+	Count      int           `json:"count"`
+	Avg        DNSAvg        `json:"avg"`
+	Quantiles  DNSQuantiles  `json:"quantiles"`
+	Dimensions DNSDimensions `json:"dimensions"`
+}
+
+type DNSAvg struct {
+	ResponseTime float64 `json:"responseTime"`
+}
+
+type DNSQuantiles struct {
+	ResponseTimeP50 float64 `json:"responseTimeP50"`
+	ResponseTimeP90 float64 `json:"responseTimeP90"`
+	ResponseTimeP99 float64 `json:"responseTimeP99"`
+}
+
+type DNSDimensions struct {
+	QueryName      string `json:"queryName"`
+	QueryType      string `json:"queryType"`
+	ResponseCode   string `json:"responseCode"`
+	ResponseCached int    `json:"responseCached"`
+	ColoName       string `json:"coloName"`
+	IPVersion      int    `json:"ipVersion"`
+	Protocol       string `json:"protocol"`
+	QueryEDNS      int    `json:"queryEdns"`
+}
+
+type CacheGroup struct {
+	Count      int             `json:"count"`
+	Sum        CacheSum        `json:"sum"`
+	Max        CacheMax        `json:"max"`
+	Dimensions CacheDimensions `json:"dimensions"`
+}
+
+type CacheSum struct {
+	EdgeResponseBytes int `json:"edgeResponseBytes"`
+	Requests          int `json:"requests"`
+}
+
+type CacheMax struct {
+	StoredBytes int `json:"storedBytes"`
+	ObjectCount int `json:"objectCount"`
+}
+
+type CacheDimensions struct {
+	CacheStatus       string `json:"cacheStatus"`
+	UpperTierColoName string `json:"upperTierColoName"`
+	Host              string `json:"clientRequestHTTPHost"`
+	OperationClass    string `json:"operationClass"`
+}
+
 type FwEvent struct {
 	Count      int          `json:"count"`
 	Dimensions FwDimensions `json:"dimensions"`
@@ -140,7 +402,9 @@ type FwDimensions struct {
 	Action  string `json:"action"`
 	ASName  string `json:"clientASNDescription"`
 	Country string `json:"clientCountryName"`
+	Host    string `json:"clientRequestHTTPHost"`
 	RuleID  string `json:"ruleId"`
+	Source  string `json:"source"`
 }
 
 func buildGraphQLQuery(queryString, startDate, endDate, zoneID, accountID string) *graphql.Request {
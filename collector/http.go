@@ -9,6 +9,7 @@ func getCloudflareHTTPMetrics(startDate string, endDate string, zoneID string, a
 					limit: 10000
 					filter: {datetimeMinute_geq: $startDate, datetimeMinute_leq: $endDate}
 				) {
+					count
 					dimensions {
 						cacheStatus
 						clientCountryName
@@ -63,3 +64,39 @@ func getCloudflareHTTPMetrics(startDate string, endDate string, zoneID string, a
 	response, err := doGraphQLQuery(request, apiEmail, apiKey)
 	return response, err
 }
+
+// supportedQuantiles are the quantiles httpRequestsAdaptiveGroups is able to return
+var supportedQuantiles = []float64{0.5, 0.75, 0.9, 0.95, 0.99, 0.999}
+
+func getCloudflareHTTPLatencyMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			zones(filter: { zoneTag: $zoneTag }) {
+				latency: httpRequestsAdaptiveGroups(
+					limit: 1
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					quantiles {
+						edgeTimeToFirstByteMsP50
+						edgeTimeToFirstByteMsP75
+						edgeTimeToFirstByteMsP90
+						edgeTimeToFirstByteMsP95
+						edgeTimeToFirstByteMsP99
+						edgeTimeToFirstByteMsP999
+						originResponseDurationMsP50
+						originResponseDurationMsP75
+						originResponseDurationMsP90
+						originResponseDurationMsP95
+						originResponseDurationMsP99
+						originResponseDurationMsP999
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, zoneID, "")
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
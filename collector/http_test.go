@@ -17,3 +17,19 @@ func TestHTTPMetrics(t *testing.T) {
 		t.Logf("Test succeeded with %v and %v", os.Getenv("apiEmail"), os.Getenv("apiKey"))
 	}
 }
+
+func TestParseQuantiles(t *testing.T) {
+	quantiles, err := parseQuantiles("0.5, 0.99,0.999")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(quantiles) != 3 {
+		t.Fatalf("Expected 3 quantiles, got %v", quantiles)
+	}
+	if suffix := quantileSuffix(quantiles[2]); suffix != "P999" {
+		t.Errorf("Expected P999, got %s", suffix)
+	}
+	if _, err := parseQuantiles("0.42"); err == nil {
+		t.Errorf("Expected an error for an unsupported quantile")
+	}
+}
@@ -0,0 +1,28 @@
+package collector
+
+import (
+	"strconv"
+	"time"
+)
+
+type LogpushJob struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Dataset      string    `json:"dataset"`
+	Enabled      bool      `json:"enabled"`
+	LastComplete time.Time `json:"last_complete"`
+	LastError    time.Time `json:"last_error"`
+	ErrorMessage string    `json:"error_message"`
+}
+
+// jobID returns the ID of the job as a label value
+func (job LogpushJob) jobID() string {
+	return strconv.Itoa(job.ID)
+}
+
+// getCloudflareLogpushJobs lists the Logpush jobs of a zone or account, scope being "zones" or "accounts"
+func getCloudflareLogpushJobs(scope, id, mail, key string) ([]LogpushJob, error) {
+	var jobs []LogpushJob
+	err := getCloudflareRESTResult(apiURL+"/"+scope+"/"+id+"/logpush/jobs", mail, key, &jobs)
+	return jobs, err
+}
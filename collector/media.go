@@ -0,0 +1,64 @@
+package collector
+
+type ImagesStats struct {
+	Count ImagesCount `json:"count"`
+}
+
+type ImagesCount struct {
+	Current int `json:"current"`
+	Allowed int `json:"allowed"`
+}
+
+type StreamStorageUsage struct {
+	VideoCount               int     `json:"videoCount"`
+	TotalStorageMinutes      float64 `json:"totalStorageMinutes"`
+	TotalStorageMinutesLimit float64 `json:"totalStorageMinutesLimit"`
+}
+
+func getCloudflareMediaMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				imagesTransformations: imagesUniqueTransformationsAdaptiveGroups(
+					limit: 1
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						transformations
+					}
+				}
+				imagesDelivered: imagesRequestsAdaptiveGroups(
+					limit: 1
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						requests
+					}
+				}
+				streamMinutesViewed: streamMinutesViewedAdaptiveGroups(
+					limit: 1
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						minutesViewed
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+func getCloudflareImagesStats(accountID, mail, key string) (stats ImagesStats, err error) {
+	err = getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/images/v1/stats", mail, key, &stats)
+	return stats, err
+}
+
+func getCloudflareStreamStorageUsage(accountID, mail, key string) (usage StreamStorageUsage, err error) {
+	err = getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/stream/storage-usage", mail, key, &usage)
+	return usage, err
+}
@@ -1,5 +1,7 @@
 package collector
 
+import "net"
+
 func getCloudflareNetworkMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
 	query := `
 	{
@@ -31,3 +33,152 @@ func getCloudflareNetworkMetrics(startDate string, endDate string, accountID str
 	response, err := doGraphQLQuery(request, apiEmail, apiKey)
 	return response, err
 }
+
+type MagicTunnel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type IPPrefix struct {
+	ID          string `json:"id"`
+	CIDR        string `json:"cidr"`
+	Description string `json:"description"`
+}
+
+func getCloudflareMagicTransitMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+		  accounts(filter: { accountTag: $accountTag }) {
+			magicFirewall: magicFirewallNetworkAnalyticsAdaptiveGroups(
+			  limit: 10000
+			  filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+			) {
+			  sum {
+				bits
+				packets
+			  }
+			  dimensions {
+				ruleId
+				outcome
+			  }
+			}
+			magicTunnels: magicTransitTunnelTrafficAdaptiveGroups(
+			  limit: 10000
+			  filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+			) {
+			  sum {
+				bits
+				packets
+			  }
+			  dimensions {
+				tunnelName
+				direction
+			  }
+			}
+			magicHealthChecks: magicTransitTunnelHealthChecksAdaptiveGroups(
+			  limit: 10000
+			  filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+			) {
+			  count
+			  dimensions {
+				tunnelName
+				resultStatus
+			  }
+			}
+			magicPrefixes: magicTransitNetworkAnalyticsAdaptiveGroups(
+			  limit: 10000
+			  filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+			) {
+			  sum {
+				bits
+				packets
+			  }
+			  dimensions {
+				ipDestinationSubnet
+				outcome
+			  }
+			}
+		  }
+		}
+	  }
+	`
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareMagicTunnels maps the name of every GRE and IPsec tunnel of the account to its type
+func getCloudflareMagicTunnels(accountID, mail, key string) (map[string]string, error) {
+	var gre struct {
+		Tunnels []MagicTunnel `json:"gre_tunnels"`
+	}
+	err := getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/magic/gre_tunnels", mail, key, &gre)
+	if err != nil {
+		return nil, err
+	}
+	var ipsec struct {
+		Tunnels []MagicTunnel `json:"ipsec_tunnels"`
+	}
+	err = getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/magic/ipsec_tunnels", mail, key, &ipsec)
+	if err != nil {
+		return nil, err
+	}
+	tunnels := make(map[string]string)
+	for _, tunnel := range gre.Tunnels {
+		tunnels[tunnel.Name] = "gre"
+	}
+	for _, tunnel := range ipsec.Tunnels {
+		tunnels[tunnel.Name] = "ipsec"
+	}
+	return tunnels, nil
+}
+
+func getCloudflareIPPrefixes(accountID, mail, key string) ([]IPPrefix, error) {
+	var prefixes []IPPrefix
+	err := getCloudflareRESTResult(apiURL+"/accounts/"+accountID+"/addressing/prefixes", mail, key, &prefixes)
+	return prefixes, err
+}
+
+// prefixForSubnet returns the account prefix that contains the subnet, or the subnet itself when none does
+func prefixForSubnet(prefixes []IPPrefix, subnet string) IPPrefix {
+	ip, _, err := net.ParseCIDR(subnet)
+	if err != nil {
+		ip = net.ParseIP(subnet)
+	}
+	for _, prefix := range prefixes {
+		_, network, err := net.ParseCIDR(prefix.CIDR)
+		if err == nil && ip != nil && network.Contains(ip) {
+			return prefix
+		}
+	}
+	return IPPrefix{CIDR: subnet}
+}
+
+func getCloudflareAttackTimeline(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+		  accounts(filter: { accountTag: $accountTag }) {
+			attackTimeline: ipFlows1mGroups(
+			  limit: 10000
+			  filter: {datetimeMinute_geq: $startDate, datetimeMinute_leq: $endDate, attackId_neq: ""}
+			) {
+			  sum {
+				bits
+				packets
+			  }
+			  networkDimensions:dimensions {
+				attackId
+				datetimeMinute
+			  }
+			}
+		  }
+		}
+	  }
+	`
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
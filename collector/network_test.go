@@ -3,6 +3,7 @@ package collector
 import (
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -19,3 +20,49 @@ func TestNetworkMetrics(t *testing.T) {
 		t.Logf("Test succeeded with %v and %v", os.Getenv("apiEmail"), os.Getenv("apiKey"))
 	}
 }
+
+func TestPrefixForSubnet(t *testing.T) {
+	prefixes := []IPPrefix{
+		{CIDR: "192.0.2.0/24", Description: "office"},
+		{CIDR: "198.51.100.0/22", Description: "datacenter"},
+	}
+	if prefix := prefixForSubnet(prefixes, "198.51.101.0/24"); prefix.Description != "datacenter" {
+		t.Errorf("Expected datacenter, got %v", prefix)
+	}
+	if prefix := prefixForSubnet(prefixes, "203.0.113.0/24"); prefix.CIDR != "203.0.113.0/24" || prefix.Description != "" {
+		t.Errorf("Expected the subnet itself, got %v", prefix)
+	}
+}
+
+func TestAttackTable(t *testing.T) {
+	start := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	table := make(attackTable)
+	table.update("attack", start.Add(time.Minute), 600, 60)
+	table.update("attack", start, 6000, 120)
+
+	a := table["attack"]
+	if !a.Start.Equal(start) || !a.LastSeen.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("Unexpected attack window %v - %v", a.Start, a.LastSeen)
+	}
+	if a.PeakBPS != 100 || a.PeakPPS != 2 {
+		t.Errorf("Unexpected peaks %v bps %v pps", a.PeakBPS, a.PeakPPS)
+	}
+
+	path := filepath.Join(t.TempDir(), "attacks.json")
+	if err := table.save(path); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	loaded, err := loadAttackTable(path)
+	if err != nil || loaded["attack"] == nil || loaded["attack"].PeakBPS != 100 {
+		t.Fatalf("Unable to load the saved table: %v %v", loaded, err)
+	}
+
+	loaded.expire(start.Add(10*time.Minute), 15*time.Minute)
+	if len(loaded) != 1 {
+		t.Errorf("Attack expired before the quiet period")
+	}
+	loaded.expire(start.Add(20*time.Minute), 15*time.Minute)
+	if len(loaded) != 0 {
+		t.Errorf("Attack did not expire after the quiet period")
+	}
+}
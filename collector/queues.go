@@ -0,0 +1,67 @@
+package collector
+
+import "encoding/json"
+
+type Queue struct {
+	ID   string `json:"queue_id"`
+	Name string `json:"queue_name"`
+}
+
+// queueStats stores the message counters of a queue, aggregated from the message operations
+type queueStats struct {
+	produced     int
+	consumed     int
+	retried      int
+	deadLettered int
+}
+
+func getCloudflareQueuesMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				queueOperations: queueMessageOperationsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						queueId
+						actionType
+						outcome
+					}
+				}
+				queueBacklog: queueBacklogAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					avg {
+						messages
+						bytes
+					}
+					dimensions {
+						queueId
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareQueues maps the ID of every queue of the account to its name
+func getCloudflareQueues(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/queues", mail, key, func(result json.RawMessage) error {
+		var queues []Queue
+		err := json.Unmarshal(result, &queues)
+		for _, queue := range queues {
+			names[queue.ID] = queue.Name
+		}
+		return err
+	})
+	return names, err
+}
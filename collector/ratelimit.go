@@ -0,0 +1,48 @@
+package collector
+
+type RateLimitRule struct {
+	ID          string           `json:"id"`
+	Description string           `json:"description"`
+	Action      string           `json:"action"`
+	Enabled     bool             `json:"enabled"`
+	RateLimit   RateLimitSetting `json:"ratelimit"`
+}
+
+type RateLimitSetting struct {
+	Characteristics   []string `json:"characteristics"`
+	Period            int      `json:"period"`
+	RequestsPerPeriod int      `json:"requests_per_period"`
+	MitigationTimeout int      `json:"mitigation_timeout"`
+}
+
+func getCloudflareRateLimitMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			zones(filter: { zoneTag: $zoneTag }) {
+				rateLimitEvents: firewallEventsAdaptiveGroups(
+					limit: 1000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate, source: "ratelimit"}
+				) {
+					count
+					dimensions {
+						ruleId
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, zoneID, "")
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareRateLimitRules returns the rules deployed on the http_ratelimit phase of a zone
+func getCloudflareRateLimitRules(zoneID, mail, key string) ([]RateLimitRule, error) {
+	var ruleset struct {
+		Rules []RateLimitRule `json:"rules"`
+	}
+	err := getCloudflareRESTResult(apiURL+"/zones/"+zoneID+"/rulesets/phases/http_ratelimit/entrypoint", mail, key, &ruleset)
+	return ruleset.Rules, err
+}
@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const rateLimitPayload = `{
+	"viewer": {
+		"zones": [{
+			"rateLimitEvents": [
+				{"count": 18, "dimensions": {"ruleId": "2e4f4cbbbb1c4f0ea1c8ab9d5c8e5b0a"}}
+			]
+		}]
+	}
+}`
+
+const rateLimitRulesetPayload = `{
+	"id": "5a3e0a2b1c3d4e5f6a7b8c9d0e1f2a3b",
+	"phase": "http_ratelimit",
+	"rules": [{
+		"id": "2e4f4cbbbb1c4f0ea1c8ab9d5c8e5b0a",
+		"description": "Login brute force",
+		"action": "block",
+		"enabled": true,
+		"ratelimit": {
+			"characteristics": ["ip.src", "cf.colo.id"],
+			"period": 60,
+			"requests_per_period": 20,
+			"mitigation_timeout": 600
+		}
+	}]
+}`
+
+func TestRateLimitResponse(t *testing.T) {
+	var resp RespDataStruct
+	if err := json.Unmarshal([]byte(rateLimitPayload), &resp); err != nil {
+		t.Fatalf("Error decoding the response: %v", err)
+	}
+	event := resp.Viewer.Zones[0].RateLimitEvents[0]
+	if event.Count != 18 || event.Dimensions.RuleID != "2e4f4cbbbb1c4f0ea1c8ab9d5c8e5b0a" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	var ruleset struct {
+		Rules []RateLimitRule `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(rateLimitRulesetPayload), &ruleset); err != nil {
+		t.Fatalf("Error decoding the ruleset: %v", err)
+	}
+	rule := ruleset.Rules[0]
+	if !rule.Enabled || rule.RateLimit.Period != 60 || rule.RateLimit.RequestsPerPeriod != 20 || rule.ID != event.Dimensions.RuleID {
+		t.Errorf("Unexpected rule %+v", rule)
+	}
+}
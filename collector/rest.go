@@ -0,0 +1,99 @@
+package collector
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const apiURL = "https://api.cloudflare.com/client/v4"
+
+type RESTResponse struct {
+	Success    bool            `json:"success"`
+	Errors     []RESTError     `json:"errors"`
+	Result     json.RawMessage `json:"result"`
+	ResultInfo RESTResultInfo  `json:"result_info"`
+}
+
+type RESTError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type RESTResultInfo struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+	Count      int `json:"count"`
+	TotalCount int `json:"total_count"`
+}
+
+// hasMorePages reports whether there is a page after the given one. Not every endpoint
+// fills total_pages, so it falls back to total_count and, failing that, to whether the page was full.
+func (info RESTResultInfo) hasMorePages(page int) bool {
+	switch {
+	case info.TotalPages > 0:
+		return page < info.TotalPages
+	case info.TotalCount > 0 && info.PerPage > 0:
+		return page*info.PerPage < info.TotalCount
+	default:
+		return info.PerPage > 0 && info.Count == info.PerPage
+	}
+}
+
+func getCloudflareRESTResponse(uri, mail, key string) (response RESTResponse, err error) {
+	res, requestErr := doRequest(uri, mail, key)
+	if requestErr != nil && len(res) == 0 {
+		return response, errors.Wrap(requestErr, "error making Request")
+	}
+	err = json.Unmarshal(res, &response)
+	if err != nil {
+		if requestErr != nil {
+			return response, errors.Wrap(requestErr, "error making Request")
+		}
+		return response, errors.Wrap(err, "error decoding Response")
+	}
+	if !response.Success || requestErr != nil {
+		messages := []string{}
+		for _, e := range response.Errors {
+			messages = append(messages, strconv.Itoa(e.Code)+": "+e.Message)
+		}
+		if len(messages) == 0 && requestErr != nil {
+			return response, errors.Wrap(requestErr, "error making Request")
+		}
+		return response, errors.New("request to " + uri + " failed: " + strings.Join(messages, ", "))
+	}
+	return response, nil
+}
+
+// getCloudflareRESTResult decodes the result of a REST endpoint into result
+func getCloudflareRESTResult(uri, mail, key string, result interface{}) error {
+	response, err := getCloudflareRESTResponse(uri, mail, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// getCloudflareRESTList walks every page of a list endpoint, handing the result of each page to add
+func getCloudflareRESTList(uri, mail, key string, add func(result json.RawMessage) error) error {
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	for page := 1; ; page++ {
+		response, err := getCloudflareRESTResponse(uri+separator+"page="+strconv.Itoa(page), mail, key)
+		if err != nil {
+			return err
+		}
+		err = add(response.Result)
+		if err != nil {
+			return err
+		}
+		if !response.ResultInfo.hasMorePages(page) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHasMorePages(t *testing.T) {
+	expected := []struct {
+		info RESTResultInfo
+		page int
+		more bool
+	}{
+		{RESTResultInfo{TotalPages: 3}, 2, true},
+		{RESTResultInfo{TotalPages: 3}, 3, false},
+		{RESTResultInfo{PerPage: 100, TotalCount: 250}, 2, true},
+		{RESTResultInfo{PerPage: 100, TotalCount: 200}, 2, false},
+		{RESTResultInfo{PerPage: 100, Count: 100}, 1, true},
+		{RESTResultInfo{PerPage: 100, Count: 42}, 1, false},
+		{RESTResultInfo{}, 1, false},
+	}
+	for _, e := range expected {
+		if more := e.info.hasMorePages(e.page); more != e.more {
+			t.Errorf("%+v on page %d: got %v, expected %v", e.info, e.page, more, e.more)
+		}
+	}
+}
+
+func TestRESTResponseErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`<html>upstream unavailable</html>`))
+		default:
+			w.Write([]byte(`{"success":true,"result":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	_, err := getCloudflareRESTResponse(server.URL+"/forbidden", "", "")
+	if err == nil || !strings.Contains(err.Error(), "Authentication error") {
+		t.Errorf("Expected the API error, got %v", err)
+	}
+	_, err = getCloudflareRESTResponse(server.URL+"/unavailable", "", "")
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Expected the HTTP status, got %v", err)
+	}
+	_, err = getCloudflareRESTResponse(server.URL+"/ok", "", "")
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// rumVitals are the Core Web Vitals exported per site, as named on rumWebVitalsEventsAdaptiveGroups
+var rumVitals = map[string]string{
+	"largestContentfulPaint": "largest_contentful_paint",
+	"firstInputDelay":        "first_input_delay",
+	"interactionToNextPaint": "interaction_to_next_paint",
+	"cumulativeLayoutShift":  "cumulative_layout_shift",
+}
+
+// rumPercentiles are the percentiles exported for every Core Web Vital
+var rumPercentiles = []string{"50", "75", "90"}
+
+type RUMSite struct {
+	SiteTag string `json:"site_tag"`
+	Host    string `json:"host"`
+}
+
+// rumKey identifies the series of a site, aggregated per path prefix
+type rumKey struct {
+	site       string
+	pathPrefix string
+	country    string
+	deviceType string
+}
+
+// rumVitalsStats accumulates the quantiles of several paths weighted by their number of events
+type rumVitalsStats struct {
+	count     int
+	quantiles map[string]float64
+}
+
+// pathPrefix returns the first segment of a path, /blog/post becomes /blog
+func pathPrefix(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+	return "/" + segments[0]
+}
+
+func getCloudflareRUMMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				rumPageloads: rumPerformanceEventsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					dimensions {
+						siteTag
+						requestPath
+						countryName
+						deviceType
+					}
+				}
+				rumWebVitals: rumWebVitalsEventsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					count
+					quantiles {
+						largestContentfulPaintP50
+						largestContentfulPaintP75
+						largestContentfulPaintP90
+						firstInputDelayP50
+						firstInputDelayP75
+						firstInputDelayP90
+						interactionToNextPaintP50
+						interactionToNextPaintP75
+						interactionToNextPaintP90
+						cumulativeLayoutShiftP50
+						cumulativeLayoutShiftP75
+						cumulativeLayoutShiftP90
+					}
+					dimensions {
+						siteTag
+						requestPath
+						countryName
+						deviceType
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareRUMSites maps the tag of every Web Analytics site of the account to its hostname
+func getCloudflareRUMSites(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/rum/site_info/list?per_page=100", mail, key, func(result json.RawMessage) error {
+		var sites []RUMSite
+		err := json.Unmarshal(result, &sites)
+		for _, site := range sites {
+			names[site.SiteTag] = site.Host
+		}
+		return err
+	})
+	return names, err
+}
@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+	"gopkg.in/yaml.v2"
+)
+
+// PolicyRule is a check a zone setting must pass, either matching equals or one of the values of in
+type PolicyRule struct {
+	Name    string   `yaml:"name"`
+	Setting string   `yaml:"setting"`
+	Equals  string   `yaml:"equals"`
+	In      []string `yaml:"in"`
+}
+
+// Policy lists the rules every zone must comply with
+type Policy struct {
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+func loadPolicy(path string) (Policy, error) {
+	var policy Policy
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return policy, err
+	}
+	err = yaml.Unmarshal(content, &policy)
+	return policy, err
+}
+
+// passes checks the rule against the flattened zone settings, a missing setting never passes
+func (rule PolicyRule) passes(settings map[string]string) bool {
+	value, ok := settings[rule.Setting]
+	if !ok {
+		return false
+	}
+	if len(rule.In) > 0 {
+		return contains(rule.In, value)
+	}
+	return value == rule.Equals
+}
+
+// flattenSettings turns the zone settings into plain strings, nested values are
+// stored with dotted keys like security_header.strict_transport_security.enabled
+func flattenSettings(settings []cloudflare.ZoneSetting) map[string]string {
+	flat := make(map[string]string)
+	for _, setting := range settings {
+		flattenSetting(flat, setting.ID, setting.Value)
+	}
+	return flat
+}
+
+func flattenSetting(flat map[string]string, key string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flattenSetting(flat, key+"."+k, v[k])
+		}
+	case []interface{}:
+		return
+	case float64:
+		flat[key] = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		flat[key] = ""
+	default:
+		flat[key] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,35 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestPolicyRules(t *testing.T) {
+	settings := flattenSettings([]cloudflare.ZoneSetting{
+		{ID: "always_use_https", Value: "on"},
+		{ID: "min_tls_version", Value: "1.0"},
+		{ID: "security_header", Value: map[string]interface{}{
+			"strict_transport_security": map[string]interface{}{"enabled": true, "max_age": float64(31536000)},
+		}},
+	})
+	if settings["security_header.strict_transport_security.max_age"] != "31536000" {
+		t.Errorf("Unexpected max_age %q", settings["security_header.strict_transport_security.max_age"])
+	}
+
+	expected := []struct {
+		rule   PolicyRule
+		passes bool
+	}{
+		{PolicyRule{Setting: "always_use_https", Equals: "on"}, true},
+		{PolicyRule{Setting: "min_tls_version", In: []string{"1.2", "1.3"}}, false},
+		{PolicyRule{Setting: "security_header.strict_transport_security.enabled", Equals: "true"}, true},
+		{PolicyRule{Setting: "waf", Equals: "on"}, false},
+	}
+	for _, e := range expected {
+		if e.rule.passes(settings) != e.passes {
+			t.Errorf("Expected %s to return %v", e.rule.Setting, e.passes)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+type SpectrumApp struct {
+	ID       string `json:"id"`
+	Protocol string `json:"protocol"`
+	DNS      struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"dns"`
+}
+
+type SpectrumSummary struct {
+	Data []struct {
+		Dimensions []string  `json:"dimensions"`
+		Metrics    []float64 `json:"metrics"`
+	} `json:"data"`
+}
+
+// SpectrumEvents are the metrics of an application and colo, decoded from the positional summary report
+type SpectrumEvents struct {
+	AppID         string
+	Colo          string
+	Connections   float64
+	BytesIngress  float64
+	BytesEgress   float64
+	DurationAvgMs float64
+}
+
+var (
+	spectrumDimensions = []string{"appID", "coloName"}
+	spectrumMetrics    = []string{"count", "bytesIngress", "bytesEgress", "durationAvg"}
+)
+
+// getCloudflareSpectrumMetrics returns the connections closed during the period, per application and colo
+func getCloudflareSpectrumMetrics(startDate, endDate, zoneID, mail, key string) ([]SpectrumEvents, error) {
+	v := url.Values{}
+	v.Set("since", startDate)
+	v.Set("until", endDate)
+	v.Set("metrics", strings.Join(spectrumMetrics, ","))
+	v.Set("dimensions", strings.Join(spectrumDimensions, ","))
+	v.Set("filters", "event==disconnect")
+
+	var summary SpectrumSummary
+	err := getCloudflareRESTResult(apiURL+"/zones/"+zoneID+"/spectrum/analytics/events/summary?"+v.Encode(), mail, key, &summary)
+	if err != nil {
+		return nil, err
+	}
+	events := []SpectrumEvents{}
+	for _, row := range summary.Data {
+		if len(row.Dimensions) != len(spectrumDimensions) || len(row.Metrics) != len(spectrumMetrics) {
+			continue
+		}
+		events = append(events, SpectrumEvents{
+			AppID:         row.Dimensions[0],
+			Colo:          row.Dimensions[1],
+			Connections:   row.Metrics[0],
+			BytesIngress:  row.Metrics[1],
+			BytesEgress:   row.Metrics[2],
+			DurationAvgMs: row.Metrics[3],
+		})
+	}
+	return events, nil
+}
+
+// getCloudflareSpectrumApps returns the Spectrum applications of a zone, keyed by ID
+func getCloudflareSpectrumApps(zoneID, mail, key string) (map[string]SpectrumApp, error) {
+	byID := make(map[string]SpectrumApp)
+	err := getCloudflareRESTList(apiURL+"/zones/"+zoneID+"/spectrum/apps?per_page=100", mail, key, func(result json.RawMessage) error {
+		var apps []SpectrumApp
+		err := json.Unmarshal(result, &apps)
+		for _, app := range apps {
+			byID[app.ID] = app
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return byID, nil
+}
@@ -0,0 +1,38 @@
+package collector
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+// loadStateFile decodes the JSON state stored on path into state, a missing file leaves state untouched
+func loadStateFile(path string, state interface{}) error {
+	if path == "" {
+		return nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, state)
+}
+
+// saveStateFile writes the state to a temporary file and renames it so a crash never leaves a truncated state file
+func saveStateFile(path string, state interface{}) error {
+	if path == "" {
+		return nil
+	}
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(path+".tmp", content, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
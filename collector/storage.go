@@ -0,0 +1,112 @@
+package collector
+
+import "encoding/json"
+
+type KVNamespace struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type DurableObjectNamespace struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func getCloudflareStorageMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+	{
+		viewer {
+			accounts(filter: { accountTag: $accountTag }) {
+				kvOperations: kvOperationsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						requests
+					}
+					dimensions {
+						namespaceId
+						actionType
+					}
+				}
+				r2Operations: r2OperationsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						requests
+					}
+					dimensions {
+						bucketName
+						actionType
+					}
+				}
+				r2Storage: r2StorageAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					max {
+						objectCount
+						payloadSize
+						metadataSize
+					}
+					dimensions {
+						bucketName
+					}
+				}
+				durableObjectsInvocations: durableObjectsInvocationsAdaptiveGroups(
+					limit: 10000
+					filter: {datetime_geq: $startDate, datetime_leq: $endDate}
+				) {
+					sum {
+						requests
+						wallTime
+					}
+					dimensions {
+						namespaceId
+					}
+				}
+				durableObjectsStorage: durableObjectsStorageGroups(
+					limit: 1
+					filter: {datetimeHour_geq: $startDate, datetimeHour_leq: $endDate}
+				) {
+					max {
+						storedBytes
+					}
+				}
+			}
+		}
+	}
+  `
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+// getCloudflareKVNamespaces maps the ID of every Workers KV namespace of the account to its title
+func getCloudflareKVNamespaces(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/storage/kv/namespaces?per_page=100", mail, key, func(result json.RawMessage) error {
+		var namespaces []KVNamespace
+		err := json.Unmarshal(result, &namespaces)
+		for _, namespace := range namespaces {
+			names[namespace.ID] = namespace.Title
+		}
+		return err
+	})
+	return names, err
+}
+
+// getCloudflareDurableObjectNamespaces maps the ID of every Durable Objects namespace of the account to its name
+func getCloudflareDurableObjectNamespaces(accountID, mail, key string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/workers/durable_objects/namespaces", mail, key, func(result json.RawMessage) error {
+		var namespaces []DurableObjectNamespace
+		err := json.Unmarshal(result, &namespaces)
+		for _, namespace := range namespaces {
+			names[namespace.ID] = namespace.Name
+		}
+		return err
+	})
+	return names, err
+}
@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// tunnelStatuses are the states a Cloudflare Tunnel can be in
+var tunnelStatuses = []string{"healthy", "degraded", "down", "inactive"}
+
+type Tunnel struct {
+	ID              string             `json:"id"`
+	Name            string             `json:"name"`
+	Status          string             `json:"status"`
+	ConnsActiveAt   *time.Time         `json:"conns_active_at"`
+	ConnsInactiveAt *time.Time         `json:"conns_inactive_at"`
+	Connections     []TunnelConnection `json:"connections"`
+}
+
+type TunnelConnection struct {
+	ColoName           string `json:"colo_name"`
+	ClientID           string `json:"client_id"`
+	ClientVersion      string `json:"client_version"`
+	IsPendingReconnect bool   `json:"is_pending_reconnect"`
+}
+
+// lastChange returns the last time the tunnel gained or lost all its connections
+func (tunnel Tunnel) lastChange() time.Time {
+	var last time.Time
+	if tunnel.ConnsActiveAt != nil {
+		last = *tunnel.ConnsActiveAt
+	}
+	if tunnel.ConnsInactiveAt != nil && tunnel.ConnsInactiveAt.After(last) {
+		last = *tunnel.ConnsInactiveAt
+	}
+	return last
+}
+
+func getCloudflareTunnels(accountID, mail, key string) ([]Tunnel, error) {
+	tunnels := []Tunnel{}
+	err := getCloudflareRESTList(apiURL+"/accounts/"+accountID+"/cfd_tunnel?is_deleted=false&per_page=100", mail, key, func(result json.RawMessage) error {
+		var page []Tunnel
+		err := json.Unmarshal(result, &page)
+		tunnels = append(tunnels, page...)
+		return err
+	})
+	return tunnels, err
+}
@@ -1,5 +1,30 @@
 package collector
 
+import (
+	"log"
+	"time"
+)
+
+type Ruleset struct {
+	ID    string        `json:"id"`
+	Name  string        `json:"name"`
+	Kind  string        `json:"kind"`
+	Phase string        `json:"phase"`
+	Rules []RulesetRule `json:"rules"`
+}
+
+type RulesetRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Action      string `json:"action"`
+}
+
+// ruleDescriptions caches the description of every rule deployed on a zone
+type ruleDescriptions struct {
+	descriptions map[string]string
+	updated      time.Time
+}
+
 func getCloudflareWAFMetrics(startDate string, endDate string, zoneID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
 
 	query := `
@@ -15,7 +40,9 @@ func getCloudflareWAFMetrics(startDate string, endDate string, zoneID string, ap
 			  action
 			  clientCountryName
 			  clientASNDescription
+			  clientRequestHTTPHost
 			  ruleId
+			  source
 			}
 		  }  
 		}
@@ -27,3 +54,27 @@ func getCloudflareWAFMetrics(startDate string, endDate string, zoneID string, ap
 	response, err := doGraphQLQuery(request, apiEmail, apiKey)
 	return response, err
 }
+
+// getCloudflareRuleDescriptions maps the ID of every rule in the zone rulesets to its description
+func getCloudflareRuleDescriptions(zoneID, mail, key string) (map[string]string, error) {
+	var rulesets []Ruleset
+	err := getCloudflareRESTResult(apiURL+"/zones/"+zoneID+"/rulesets", mail, key, &rulesets)
+	if err != nil {
+		return nil, err
+	}
+	descriptions := make(map[string]string)
+	for _, ruleset := range rulesets {
+		var detail Ruleset
+		err := getCloudflareRESTResult(apiURL+"/zones/"+zoneID+"/rulesets/"+ruleset.ID, mail, key, &detail)
+		if err != nil {
+			log.Println("Unable to fetch ruleset", ruleset.ID, ":", err)
+			continue
+		}
+		for _, rule := range detail.Rules {
+			if rule.Description != "" {
+				descriptions[rule.ID] = rule.Description
+			}
+		}
+	}
+	return descriptions, nil
+}
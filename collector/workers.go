@@ -19,9 +19,79 @@ func getCloudflareWorkerMetrics(startDate string, endDate string, accountID stri
 					cpuTimeP75
 					cpuTimeP99
 					cpuTimeP999
+					wallTimeP50
+					wallTimeP75
+					wallTimeP99
+					wallTimeP999
+					durationP50
+					durationP75
+					durationP99
+					durationP999
 				}
 				info:dimensions {
 					scriptName
+					status
+					usageModel
+					environmentName
+					dispatchNamespaceName
+				}
+			}
+			}
+		}
+		}`
+
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+func getCloudflareWorkerCronMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+		{
+		viewer {
+			accounts(filter: {accountTag: $accountTag}) {
+			cronInvocations:workersInvocationsScheduled(
+				limit: 10000
+				filter: {datetime_geq: $startDate, datetime_leq: $endDate }
+			) {
+				scriptName
+				cron
+				status
+				datetime
+				cpuTimeUs
+			}
+			}
+		}
+		}`
+
+	request := buildGraphQLQuery(query, startDate, endDate, "", accountID)
+	response, err := doGraphQLQuery(request, apiEmail, apiKey)
+	return response, err
+}
+
+func getCloudflarePagesFunctionsMetrics(startDate string, endDate string, accountID string, apiEmail string, apiKey string) (respData RespDataStruct, err error) {
+	query := `
+		{
+		viewer {
+			accounts(filter: {accountTag: $accountTag}) {
+			pagesFunctions:pagesFunctionsInvocationsAdaptiveGroups(
+				limit: 10000
+				filter: {datetime_geq: $startDate, datetime_leq: $endDate }
+			) {
+				sum {
+					subrequests
+					requests
+					errors
+				}
+				quantiles {
+					cpuTimeP50
+					cpuTimeP75
+					cpuTimeP99
+					cpuTimeP999
+				}
+				info:dimensions {
+					scriptName
+					status
 				}
 			}
 			}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// zoneActivationsRefresh is how often the zones are listed again while one of them is pending
+const zoneActivationsRefresh = time.Hour
+
+type ZoneActivation struct {
+	ID          string     `json:"id"`
+	ActivatedOn *time.Time `json:"activated_on"`
+}
+
+// zoneActivations caches the activation time of the zones, as it is set only once per zone.
+// A zero time means the zone was not activated yet.
+type zoneActivations struct {
+	activated map[string]time.Time
+	updated   time.Time
+}
+
+// stale reports whether the zones must be listed again, because a zone was added or a pending zone may have been activated
+func (activations zoneActivations) stale(zones []cloudflare.Zone, now time.Time) bool {
+	for _, zone := range zones {
+		activated, ok := activations.activated[zone.ID]
+		if !ok || (activated.IsZero() && now.Sub(activations.updated) >= zoneActivationsRefresh) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCloudflareZoneActivations maps the ID of every zone to the time it was activated, or to a zero time if it is pending
+func getCloudflareZoneActivations(mail, key string) (map[string]time.Time, error) {
+	activations := make(map[string]time.Time)
+	err := getCloudflareRESTList(apiURL+"/zones?per_page=50", mail, key, func(result json.RawMessage) error {
+		var zones []ZoneActivation
+		err := json.Unmarshal(result, &zones)
+		for _, zone := range zones {
+			if zone.ActivatedOn != nil {
+				activations[zone.ID] = *zone.ActivatedOn
+			} else {
+				activations[zone.ID] = time.Time{}
+			}
+		}
+		return err
+	})
+	return activations, err
+}
@@ -0,0 +1,25 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestZoneActivationsStale(t *testing.T) {
+	now := time.Now()
+	activations := zoneActivations{
+		activated: map[string]time.Time{"active": now.Add(-24 * time.Hour), "pending": {}},
+		updated:   now.Add(-time.Minute),
+	}
+	if activations.stale([]cloudflare.Zone{{ID: "active"}, {ID: "pending"}}, now) {
+		t.Error("Expected the cached activations to be used")
+	}
+	if !activations.stale([]cloudflare.Zone{{ID: "active"}, {ID: "new"}}, now) {
+		t.Error("Expected a new zone to list the zones again")
+	}
+	if !activations.stale([]cloudflare.Zone{{ID: "pending"}}, now.Add(zoneActivationsRefresh)) {
+		t.Error("Expected a pending zone to list the zones again after the refresh")
+	}
+}
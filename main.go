@@ -28,12 +28,38 @@ func main() {
 	APIKey := flag.String("key", GetEnvStr("CF_KEY", ""), "Your Cloudflare API token")
 	APIMail := flag.String("email", GetEnvStr("CF_EMAIL", ""), "The email address associated with your Cloudflare API token and account")
 	AccountID := flag.String("account", GetEnvStr("CF_ACCOUNT", ""), "Account ID to be fetched")
-	zoneName := flag.String("zone", GetEnvStr("CF_ZONE", ""), "Zone Name to be fetched")
-	Dataset := flag.String("dataset", GetEnvStr("CF_DATASET", "http,waf"), "The data source you want to export, valid values are: http, network")
+	zoneName := flag.String("zone", GetEnvStr("CF_ZONE", ""), "Zone Name to be fetched, the zones dataset lists every zone regardless")
+	Dataset := flag.String("dataset", GetEnvStr("CF_DATASET", "http,waf"), "The data source you want to export, valid values are: http, net, vdns, dns, workers, waf, bots, ratelimit, storage, d1, queues, cron, pages, magic, spectrum, tunnels, gateway, access, dnsrecords, ssl, settings, zones, rum, cache, media, billing, audit, logpush")
 	PromListenAddr := flag.String("prom-port", GetEnvStr("CF_PROM_PORT", "0.0.0.0:2112"), "Prometheus Addr")
+	LatencyQuantiles := flag.String("latency-quantiles", GetEnvStr("CF_LATENCY_QUANTILES", "0.5,0.75,0.9,0.95,0.99,0.999"), "Quantiles exported on the HTTP latency metrics")
+	RulesetRefresh := flag.String("ruleset-refresh", GetEnvStr("CF_RULESET_REFRESH", "1h"), "How often the WAF rule descriptions are refreshed")
+	AccessSessionsRefresh := flag.String("access-sessions-refresh", GetEnvStr("CF_ACCESS_SESSIONS_REFRESH", "15m"), "How often the active Access sessions are counted")
+	CronStateFile := flag.String("cron-state", GetEnvStr("CF_CRON_STATE", ""), "File where the last successful run of every cron trigger is persisted")
+	AttackStateFile := flag.String("attack-state", GetEnvStr("CF_ATTACK_STATE", ""), "File where the DDoS attack table is persisted")
+	AttackQuietPeriod := flag.String("attack-quiet-period", GetEnvStr("CF_ATTACK_QUIET_PERIOD", "30m"), "Time without traffic after which an attack is expired, at least 20m")
+	DNSDesiredState := flag.String("dns-desired-state", GetEnvStr("CF_DNS_DESIRED_STATE", ""), "YAML file with the DNS records every zone is expected to have")
+	CompliancePolicy := flag.String("compliance-policy", GetEnvStr("CF_COMPLIANCE_POLICY", ""), "YAML file with the rules the zone settings are checked against")
+	AuditStateFile := flag.String("audit-state", GetEnvStr("CF_AUDIT_STATE", ""), "File where the audit log cursor is persisted")
+	AuditOutput := flag.String("audit-output", GetEnvStr("CF_AUDIT_OUTPUT", ""), "File the audit log events are forwarded to as JSON lines, use - for stdout")
 	flag.Parse()
 
-	CFCollector := collector.New(*APIKey, *APIMail, *AccountID, *zoneName, *Dataset)
+	CFCollector := collector.New(collector.Config{
+		APIKey:                *APIKey,
+		APIEmail:              *APIMail,
+		AccountID:             *AccountID,
+		ZoneName:              *zoneName,
+		Dataset:               *Dataset,
+		LatencyQuantiles:      *LatencyQuantiles,
+		RulesetRefresh:        *RulesetRefresh,
+		CronStateFile:         *CronStateFile,
+		AccessSessionsRefresh: *AccessSessionsRefresh,
+		AttackStateFile:       *AttackStateFile,
+		AttackQuietPeriod:     *AttackQuietPeriod,
+		DNSDesiredState:       *DNSDesiredState,
+		CompliancePolicy:      *CompliancePolicy,
+		AuditStateFile:        *AuditStateFile,
+		AuditOutput:           *AuditOutput,
+	})
 	prometheus.MustRegister(CFCollector)
 
 	http.Handle("/metrics", promhttp.Handler())